@@ -10,32 +10,72 @@ const (
 	MATTERMOST_USER_KEY     = "Mattermost-User-ID"
 
 	// Date format
-	DATE_FORMAT           = "Monday, January 2, 2006"
-	TIME_FORMAT           = "3:04 PM MST"
 	CUSTOM_FORMAT         = "2006-01-02@15:04"
 	CUSTOM_FORMAT_NO_TIME = "2006-01-02"
+	REMINDER_TIME_FORMAT  = "15:04"
+
+	// Time of the daily "who's out" post, in the timezone of the user who subscribed the channel
+	TEAM_DIGEST_TIME = "09:00"
+
+	// Event send updates
+	SEND_UPDATES_ALL           = "all"
+	SEND_UPDATES_EXTERNAL_ONLY = "externalOnly"
+	SEND_UPDATES_NONE          = "none"
 
 	// Command
 	MAIN_CMD       = "/calendar"
 	CONNECT_CMD    = "connect"
 	CREATE_CMD     = "create"
+	EDIT_CMD       = "edit"
 	SUMMARY_CMD    = "summary"
 	SETTINGS_CMD   = "settings"
 	NEXT_CMD       = "next"
+	AGENDA_CMD     = "agenda"
+	CONFLICTS_CMD  = "conflicts"
+	SEARCH_CMD     = "search"
+	RESPONSES_CMD  = "responses"
+	TEAM_CMD       = "team"
+	ADMIN_CMD      = "admin"
 	DISCONNECT_CMD = "disconnect"
 	HELP_CMD       = "help"
 
+	// Command flag
+	FORCE_FLAG = "--force"
+
 	// config
 	ALLOW_NOTIFY     = "Y"
 	NOT_ALLOW_NOTIFY = "N"
 
+	// Sync notification layout
+	SYNC_LAYOUT_COMBINED = "combined"
+	SYNC_LAYOUT_THREADS  = "threads"
+
+	// Digest of the calendar changes
+	DIGEST_MODE_OFF         = "off"
+	DIGEST_MODE_HOURLY      = "hourly"
+	DIGEST_MODE_TWICE_DAILY = "twice_daily"
+
+	// Times of the twice daily digest, in the timezone of the user
+	DIGEST_MORNING_TIME = "09:00"
+	DIGEST_EVENING_TIME = "17:00"
+
+	// Changes to the events starting within these minutes are never held back by the quiet hours
+	// or the digest
+	URGENT_NOTIFICATION_MINUTES = 60
+
+	// Types of notifications, each delivered where the user chose
+	NOTIFICATION_INVITES   = "invites"
+	NOTIFICATION_UPDATES   = "updates"
+	NOTIFICATION_REMINDERS = "reminders"
+	NOTIFICATION_DIGESTS   = "digests"
+
+	// Deliveries of the notifications, other than the ID of a private channel of the user
+	DELIVERY_DM        = "dm"
+	DELIVERY_EPHEMERAL = "ephemeral"
+
 	// Calendar  ID
 	PRIMARY_CALENDAR_ID = "primary"
 
-	// Error message
-	INTERNAL_ERR_USER_NOT_FOUND = "user not found"
-	ERR_CONNECT_FIRST           = "Please connect your google calendar with command => `/calendar connect`"
-
 	// Event response statutus
 	EV_STATUS_NEED_ACTION = "needsAction"
 	EV_STATUS_ACCEPTED    = "accepted"
@@ -43,11 +83,44 @@ const (
 	EV_STATUS_TENTATIVE   = "tentative"
 	EV_STATUS_CANCELLED   = "cancelled"
 
+	// Color of the event cards, by the response of the user
+	EVENT_CARD_COLOR_ACCEPTED    = "#3DB887"
+	EVENT_CARD_COLOR_DECLINED    = "#D24B4E"
+	EVENT_CARD_COLOR_TENTATIVE   = "#FFBC1F"
+	EVENT_CARD_COLOR_NEED_ACTION = "#166DE0"
+	EVENT_CARD_COLOR_CANCELLED   = "#8B8B8B"
+
+	// Actions of the event cards
+	EVENT_ACTION_JOIN    = "join"
+	EVENT_ACTION_RESPOND = "respond"
+	EVENT_ACTION_DELETE  = "delete"
+
+	// Actions of the reminders
+	REMINDER_ACTION_SNOOZE             = "snooze"
+	REMINDER_ACTION_SNOOZE_UNTIL_START = "snooze_until_start"
+	REMINDER_ACTION_MUTE               = "mute"
+
+	// Minutes a reminder is snoozed for
+	SNOOZE_MINUTES = 5
+
 	// Key
-	EVENTS_KEY        = "events"
-	WATCH_TOKEN_KEY   = "watch_token"
-	WATCH_CHANNEL_KEY = "watch_channel"
-	SYNC_TOKEN_KEY    = "sync_token"
+	EVENTS_KEY               = "events"
+	WATCH_TOKEN_KEY          = "watch_token"
+	WATCH_CHANNEL_KEY        = "watch_channel"
+	SYNC_TOKEN_KEY           = "sync_token"
+	EVENTS_WINDOW_END_KEY    = "events_window_end"
+	TEAM_DIGEST_CHANNELS_KEY = "team_digest_channels"
+	SYNC_STATUS_KEY          = "sync_status"
+	TOKEN_INVALID_KEY        = "token_invalid"
+	SNOOZED_REMINDERS_KEY    = "snoozed_reminders"
+	MUTED_EVENTS_KEY         = "muted_events"
+	NOTIFICATION_QUEUE_KEY   = "notification_queue"
+
+	// Plugin KV store key prefix, followed by the channel ID
+	TEAM_DIGEST_SUBSCRIBER_KEY_PREFIX = "team_digest_"
+
+	// Key prefix, followed by the event ID
+	EVENT_THREAD_KEY_PREFIX = "event_thread_"
 
 	// SITE_URL = "https://51c9-180-180-58-99.ap.ngrok.io"
 	EMAIL_REGEX = `^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`
@@ -56,6 +129,9 @@ const (
 
 var (
 	DefaultUserSettings = model.UserSettings{
-		TimeNotiBeforeEvent: 10,
+		TimeNotiBeforeEvent:    10,
+		AllDayReminderTime:     "09:00",
+		SyncNotificationLayout: SYNC_LAYOUT_COMBINED,
+		DigestMode:             DIGEST_MODE_OFF,
 	}
 )
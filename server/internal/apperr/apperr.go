@@ -0,0 +1,181 @@
+// Package apperr holds the errors shared by the repository, service and plugin layers, and maps
+// them to the messages shown to the users and to HTTP status codes.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+var (
+	// ErrNotFound is returned by the repositories when a record doesn't exist
+	ErrNotFound = errors.New("record not found")
+
+	// ErrNotConnected is returned when the user hasn't connected their Google Calendar
+	ErrNotConnected = errors.New("google calendar not connected")
+
+	// ErrTokenRevoked is returned when Google doesn't accept the token of the user anymore
+	ErrTokenRevoked = errors.New("google calendar token revoked")
+
+	// ErrRateLimited is returned when Google asks to slow down
+	ErrRateLimited = errors.New("google calendar rate limited")
+
+	// ErrSyncTokenExpired is returned when Google requires a full sync
+	ErrSyncTokenExpired = errors.New("sync token expired")
+
+	// ErrNotOrganizer is returned when the user tries to change an event they don't organize
+	ErrNotOrganizer = errors.New("not the organizer of the event")
+
+	// ErrValidation is matched by every ValidationError
+	ErrValidation = errors.New("validation error")
+)
+
+// NotOrganizerError tells which action was refused: delete, edit, nudge or responses
+type NotOrganizerError struct {
+	Action string
+}
+
+func NotOrganizer(action string) error {
+	return &NotOrganizerError{Action: action}
+}
+
+func (e *NotOrganizerError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrNotOrganizer, e.Action)
+}
+
+func (e *NotOrganizerError) Is(target error) bool {
+	return target == ErrNotOrganizer
+}
+
+// ValidationError is an invalid input of the user, the message is shown to them as is
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func Validation(field, message string) error {
+	return &ValidationError{Field: field, Message: message}
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// GoogleError is an error returned by the Google Calendar API, classified by Kind
+type GoogleError struct {
+	Kind error
+	Err  error
+}
+
+func (e *GoogleError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Kind, e.Err)
+}
+
+func (e *GoogleError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *GoogleError) Unwrap() error {
+	return e.Err
+}
+
+// FromGoogle classifies an error of the Google Calendar API, errors that are not about the token,
+// the rate limit or the sync token are returned as is
+func FromGoogle(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		if strings.Contains(string(retrieveErr.Body), "invalid_grant") ||
+			(retrieveErr.Response != nil && retrieveErr.Response.StatusCode == http.StatusUnauthorized) {
+			return &GoogleError{Kind: ErrTokenRevoked, Err: err}
+		}
+		return err
+	}
+
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.Code {
+	case http.StatusUnauthorized:
+		return &GoogleError{Kind: ErrTokenRevoked, Err: err}
+	case http.StatusGone:
+		return &GoogleError{Kind: ErrSyncTokenExpired, Err: err}
+	case http.StatusTooManyRequests:
+		return &GoogleError{Kind: ErrRateLimited, Err: err}
+	case http.StatusForbidden:
+		for _, item := range apiErr.Errors {
+			if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+				return &GoogleError{Kind: ErrRateLimited, Err: err}
+			}
+		}
+	}
+	return err
+}
+
+// UserMessage returns the message telling the user what went wrong, in the default language
+func UserMessage(err error) string {
+	return LocalizedMessage(i18n.NewLocalizer(i18n.DefaultLocale), err)
+}
+
+// LocalizedMessage returns the message telling the user what went wrong, in their language
+func LocalizedMessage(localizer *i18n.Localizer, err error) string {
+	var validationErr *ValidationError
+	var notOrganizerErr *NotOrganizerError
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrNotConnected):
+		return localizer.T("error.not_connected")
+	case errors.Is(err, ErrTokenRevoked):
+		return localizer.T("error.token_revoked")
+	case errors.Is(err, ErrRateLimited):
+		return localizer.T("error.rate_limited")
+	case errors.As(err, &notOrganizerErr):
+		return localizer.T("error.not_organizer." + notOrganizerErr.Action)
+	case errors.Is(err, ErrNotOrganizer):
+		return localizer.T("error.not_organizer")
+	case errors.As(err, &validationErr):
+		return validationErr.Message
+	case errors.Is(err, ErrNotFound):
+		return localizer.T("error.not_found")
+	default:
+		return localizer.T("error.unknown", err.Error())
+	}
+}
+
+// HTTPStatus returns the status code of the response to a request that failed with err
+func HTTPStatus(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, ErrNotConnected), errors.Is(err, ErrTokenRevoked):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrNotOrganizer):
+		return http.StatusForbidden
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
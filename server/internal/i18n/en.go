@@ -0,0 +1,438 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+var english = &locale{
+	messages:      englishMessages,
+	weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+		"October", "November", "December"},
+	shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	am:          "AM",
+	pm:          "PM",
+	isOne:       isOneEnglish,
+	date: func(l *Localizer, t time.Time) string {
+		return fmt.Sprintf("%s, %s %d, %d", l.Weekday(t.Weekday()), l.Month(t.Month()), t.Day(), t.Year())
+	},
+	shortDate: func(l *Localizer, t time.Time) string {
+		return fmt.Sprintf("%s %d %s", l.ShortWeekday(t.Weekday()), t.Day(), l.ShortMonth(t.Month()))
+	},
+	clock12: func(l *Localizer, t time.Time) string {
+		return t.Format("3:04") + " " + l.meridiem(t)
+	},
+}
+
+var englishMessages = map[string]Message{
+	// autocomplete of the command
+	"autocomplete.calendar":            {Other: "Available commands: connect, create, edit, next, summary, agenda, conflicts, search, responses, team, settings, disconnect, help"},
+	"autocomplete.connect":             {Other: "Connect your Google Calendar with your Mattermost account"},
+	"autocomplete.create":              {Other: "Create an event with a title, Start DateTime, End DateTime, and Attendees"},
+	"autocomplete.create.title":        {Other: "Title for the event you are creating, must be surrounded by single-quotes. Example: 'my-meeting'"},
+	"autocomplete.create.start":        {Other: "Time the event starts in YYYY-MM-DD@HH:MM format (2022-01-01@12:00)."},
+	"autocomplete.create.end":          {Other: "Time the event finishes in YYYY-MM-DD@HH:MM format (2022-01-01@13:00)."},
+	"autocomplete.create.attendees":    {Other: "This is a list of username or email addresses of the people you want to invite to the event. You have to type within square brackets `[ ]` and separate each username or email address with space.\n Example: [example1 example2 example@gmail.com]"},
+	"autocomplete.create.force":        {Other: "Create the event even if it overlaps events you are going to"},
+	"autocomplete.edit":                {Other: "Edit one of your upcoming events"},
+	"autocomplete.event":               {Other: "Upcoming event you organize"},
+	"autocomplete.next":                {Other: "Get the next event of today"},
+	"autocomplete.summary":             {Other: "Get a breakdown of a particular date"},
+	"autocomplete.summary.date":        {Other: "Date can be a word [today] or [tmr] or specific date in YYYY-MM-DD format (2022-01-01)"},
+	"autocomplete.agenda":              {Other: "Get your agenda of a week or of a custom range"},
+	"autocomplete.agenda.range":        {Other: "Range can be words [this week] or [next week] or specific dates in YYYY-MM-DD..YYYY-MM-DD format, add [compact] for a dense view"},
+	"autocomplete.conflicts":           {Other: "List your events overlapping each other"},
+	"autocomplete.conflicts.range":     {Other: "Range can be words [this week] or [next week] or specific dates in YYYY-MM-DD..YYYY-MM-DD format"},
+	"autocomplete.search":              {Other: "Search your events"},
+	"autocomplete.search.text":         {Other: "Text to look for in the title, description, location and guests of the events"},
+	"autocomplete.search.from":         {Other: "Search events from this date in YYYY-MM-DD format"},
+	"autocomplete.search.to":           {Other: "Search events until this date in YYYY-MM-DD format"},
+	"autocomplete.search.attendee":     {Other: "Only search events this username or email address is invited to"},
+	"autocomplete.responses":           {Other: "See who answered an event you organize and nudge the others"},
+	"autocomplete.team":                {Other: "See who is free, in a meeting or out of office"},
+	"autocomplete.team.target":         {Other: "Channel or group to look at, this channel by default. [subscribe] posts who's out this week in this channel every weekday"},
+	"autocomplete.admin":               {Other: "Operate the plugin, system admins only"},
+	"autocomplete.admin.stats":         {Other: "Connected users, watch channels expiring soon and the last sync errors"},
+	"autocomplete.admin.user":          {Other: "Connection, last sync and token health of a user"},
+	"autocomplete.admin.username":      {Other: "Username of the user"},
+	"autocomplete.admin.resync":        {Other: "Drop the synced events and do a full sync again"},
+	"autocomplete.admin.resync.target": {Other: "Username of the user, or all"},
+	"autocomplete.admin.disconnect":    {Other: "Disconnect the Google Calendar of a user"},
+	"autocomplete.admin.renew_watches": {Other: "Set up again the push notification channels of every user"},
+	"autocomplete.settings":            {Other: "User settings, you can see and change your settings."},
+	"autocomplete.disconnect":          {Other: "Disconnect Google Calendar from your Mattermost account."},
+	"autocomplete.help":                {Other: "Display usage"},
+
+	"agenda.continued":             {Other: "%s (continued)"},
+	"agenda.loading":               {Other: "Getting your agenda..."},
+	"agenda.range.dates":           {Other: "%s - %s"},
+	"agenda.range.invalid":         {Other: "invalid range, please use [this week], [next week] or YYYY-MM-DD..YYYY-MM-DD"},
+	"agenda.range.invalid_end":     {Other: "invalid end date format, please use YYYY-MM-DD"},
+	"agenda.range.invalid_start":   {Other: "invalid start date format, please use YYYY-MM-DD"},
+	"agenda.range.next_week":       {Other: "Next Week's"},
+	"agenda.range.start_after_end": {Other: "start date must be before end date"},
+	"agenda.range.this_week":       {Other: "This Week's"},
+	"agenda.range.too_long":        {One: "range must not be longer than %d day", Other: "range must not be longer than %d days"},
+	"agenda.response.links":        {Other: "[Yes](%s) / [No](%s) / [Maybe](%s)"},
+	"agenda.table_header":          {Other: "| Time | Title | Where | Going? |"},
+	"agenda.time.all_day":          {Other: "All-day"},
+	"agenda.time.all_day_of":       {Other: "All-day, Day %d of %d"},
+	"agenda.time.day_of":           {Other: "Day %d of %d"},
+	"agenda.time.from":             {Other: "From %s, Day %d of %d"},
+	"agenda.time.until":            {Other: "Until %s, Day %d of %d"},
+	"agenda.title":                 {Other: "#### %s Agenda:\n"},
+
+	"card.delete":        {Other: "Delete"},
+	"card.guests":        {Other: "Guests"},
+	"card.guests.counts": {Other: "%d yes, %d no, %d maybe, %d awaiting"},
+	"card.join":          {Other: "Join"},
+	"card.join.link":     {Other: "[Join the meeting](%s)"},
+	"card.organizer":     {Other: "Organizer"},
+	"card.when":          {Other: "When"},
+	"card.where":         {Other: "Where"},
+
+	"changes.cancelled":         {Other: "**_Event Cancelled:_**\n\n**~~[%s](%s)~~**\n**When**: ~~%s~~\n"},
+	"changes.declined":          {Other: "**_Guests Declined:_**\n\n**[%s](%s)**\n"},
+	"changes.invited":           {Other: "**_You've been invited:_**\n"},
+	"changes.section.added":     {Other: "New Invitations"},
+	"changes.section.cancelled": {Other: "Cancelled"},
+	"changes.section.responses": {Other: "Responses"},
+	"changes.section.updated":   {Other: "Updated"},
+	"changes.title":             {Other: "#### Calendar Updates (%d):\n"},
+
+	"command.description": {Other: "Integration with Google Calendar"},
+	"command.unknown":     {Other: "Unknown command: `%v`"},
+
+	"conflicts.decline":       {Other: "  [Decline %s](%s) | [Propose a new time](%s)\n"},
+	"conflicts.double_booked": {Other: "**Double-booked?**: [Decline](%s) | [Propose a new time](%s)\n"},
+	"conflicts.empty":         {Other: "It seems that you don't have any conflicting events."},
+	"conflicts.loading":       {Other: "Looking for conflicts..."},
+	"conflicts.pair":          {Other: "- [%s](%s) %s\n  overlaps [%s](%s) %s\n"},
+	"conflicts.title":         {Other: "#### %s Conflicts:\n"},
+	"conflicts.warning":       {Other: "\n**:warning: Conflicts with:**\n"},
+
+	"connect.link":    {Other: "[Click here to link your Google Calendar.](%s)"},
+	"connect.synced":  {Other: "Google Calendar notifications are synchronized with your Mattermost!"},
+	"connect.welcome": {Other: "#### Welcome to the Mattermost Google Calendar Plugin!\nYou've successfully connected your Mattermost account to your Google Calendar.\nPlease type **/calendar help** to understand how to use this plugin."},
+
+	"date.today":    {Other: "Today"},
+	"date.tomorrow": {Other: "Tomorrow"},
+
+	"dialog.save": {Other: "Save"},
+
+	"digest.title": {One: "#### Calendar Digest (%d update):\n", Other: "#### Calendar Digest (%d updates):\n"},
+
+	"disconnect.by_admin":      {Other: "A system admin disconnected your Google Calendar, use `/calendar connect` to connect it again."},
+	"disconnect.confirm":       {Other: "Confirm"},
+	"disconnect.confirm.title": {Other: "Are you sure to disconnect?"},
+	"disconnect.failed":        {Other: "Error disconnecting calendar"},
+	"disconnect.success":       {Other: "Disconnected calendar"},
+
+	"error.not_connected":           {Other: "Please connect your google calendar with command => `/calendar connect`"},
+	"error.not_found":               {Other: "It couldn't be found, it may have been deleted."},
+	"error.not_organizer":           {Other: "You can only change events that you have created."},
+	"error.not_organizer.delete":    {Other: "You can only delete events that you have created."},
+	"error.not_organizer.edit":      {Other: "You can only edit events that you have created."},
+	"error.not_organizer.nudge":     {Other: "You can only nudge the guests of events that you have created."},
+	"error.not_organizer.responses": {Other: "You can only see the responses of events that you have created."},
+	"error.rate_limited":            {Other: "Google Calendar is receiving too many requests, please try again in a few minutes."},
+	"error.token_revoked":           {Other: "Google doesn't accept the access to your calendar anymore, please connect again with `/calendar connect`."},
+	"error.unknown":                 {Other: "Something went wrong: %s"},
+
+	"event.create.command":               {Other: "latest command is `%s`"},
+	"event.create.conflict_check":        {Other: "Failed to check your calendar for conflicts. Error: %v"},
+	"event.create.conflicts":             {Other: "The event was not created."},
+	"event.create.failed":                {Other: "Failed to create calendar event. Error: %v"},
+	"event.create.force":                 {Other: "Add `%s` at the end of the command to create it anyway."},
+	"event.create.invalid_end":           {Other: "Invalid format of end date-time: %v"},
+	"event.create.invalid_start":         {Other: "Invalid format of start date-time: %v"},
+	"event.create.missing_end":           {Other: "Missing end date-time"},
+	"event.create.missing_start":         {Other: "Missing start date-time"},
+	"event.create.missing_title":         {Other: "Missing title"},
+	"event.create.success":               {Other: "Success! Event _[%s](%s)_ on %s has been created."},
+	"event.create.title_quotes":          {Other: "Title must be in single quote"},
+	"event.delete":                       {Other: "[Delete Event](%s)\n"},
+	"event.delete.failed":                {Other: "Unable to delete event. Error: %v"},
+	"event.delete.success":               {Other: "Success! Event _%s_ has been deleted."},
+	"event.description":                  {Other: "**Description**: %s\n"},
+	"event.edit.failed":                  {Other: "Unable to edit event. Error: %v"},
+	"event.edit.field.attendees":         {Other: "Attendees"},
+	"event.edit.field.attendees.help":    {Other: "Usernames or email addresses separated with space. Example: @exampleuser-1 example@gmail.com"},
+	"event.edit.field.date.help":         {Other: "Use YYYY-MM-DD for an all-day event"},
+	"event.edit.field.description":       {Other: "Description"},
+	"event.edit.field.end":               {Other: "End DateTime"},
+	"event.edit.field.location":          {Other: "Location"},
+	"event.edit.field.send_updates":      {Other: "Send updates"},
+	"event.edit.field.start":             {Other: "Start DateTime"},
+	"event.edit.field.title":             {Other: "Title"},
+	"event.edit.field.title.placeholder": {Other: "Event Title"},
+	"event.edit.invalid_end":             {Other: "End time is invalid format-[%v]"},
+	"event.edit.invalid_start":           {Other: "Start time is invalid format-[%v]"},
+	"event.edit.mixed_all_day":           {Other: "Start and end must both be dates for an all-day event or both have a time"},
+	"event.edit.send_updates.all":        {Other: "Send to all guests"},
+	"event.edit.send_updates.external":   {Other: "Send to guests outside your organization"},
+	"event.edit.send_updates.none":       {Other: "Don't send"},
+	"event.edit.start_after_end":         {Other: "Start time must be before end time"},
+	"event.edit.title":                   {Other: "Edit Event"},
+	"event.edit.update_failed":           {Other: "Failed to update calendar event. Error: %v"},
+	"event.going":                        {Other: "**Going?**: %s"},
+	"event.going.links":                  {Other: "**Going?**: [Yes](%s) | [No](%s) | [Maybe](%s)"},
+	"event.guests":                       {Other: "**Guests**: %d yes, %d no, %d maybe, %d awaiting\n"},
+	"event.guests.added":                 {Other: "**Guests added**: %s\n"},
+	"event.guests.external":              {Other: "External"},
+	"event.guests.more":                  {Other: "& %d more"},
+	"event.guests.removed":               {Other: "**Guests removed**: ~~%s~~\n"},
+	"event.guests.team":                  {Other: "Team"},
+	"event.meet":                         {Other: "**Meet**: %s\n"},
+	"event.meet.added":                   {Other: "**Meet**: %s (added)\n"},
+	"event.meet.removed":                 {Other: "**Meet**: ~~%s~~ (removed)\n"},
+	"event.missing":                      {Other: "Missing event, please pick one of your upcoming events"},
+	"event.not_found":                    {Other: "Unable to find event `%s`"},
+	"event.organizer":                    {Other: "**Organizer**: %s\n"},
+	"event.repeats":                      {Other: "**Repeats**: %s\n"},
+	"event.repeats.none":                 {Other: "Does not repeat"},
+	"event.respond.failed":               {Other: "Unable to respond to event. Error: %v"},
+	"event.respond.success":              {Other: "Success! Event _%s_ response has been updated."},
+	"event.respond.update_failed":        {Other: "Error! Failed to update the response of _%s_ event."},
+	"event.status":                       {Other: "**Status of Event**: %s\n"},
+	"event.status.cancelled":             {Other: "Cancelled"},
+	"event.status.confirmed":             {Other: "Confirmed"},
+	"event.status.tentative":             {Other: "Tentative"},
+	"event.updated.title":                {Other: "**_Event Updated:_**\n"},
+	"event.when":                         {Other: "**When**: %s\n"},
+	"event.when.all_day":                 {Other: "%s @ All-day"},
+	"event.when.all_days":                {Other: "%s to %s (All-day)"},
+	"event.when.day_of":                  {Other: " (Day %d of %d)"},
+	"event.when.time":                    {Other: "%s @ %s to %s"},
+	"event.when.times":                   {Other: "%s @ %s to %s @ %s"},
+	"event.when.zone":                    {Other: " (%s to %s %s)"},
+	"event.where":                        {Other: "**Where**: %s\n"},
+	"events.fetch_failed":                {Other: "Error retrieving events"},
+
+	"help":       {Other: englishHelp},
+	"help.title": {Other: "###### Mattermost Google Calendar Plugin - Slash Command Help\n"},
+
+	"next.empty":   {Other: "It seems that you don't have any events happening today."},
+	"next.loading": {Other: "Getting your next event..."},
+	"next.title":   {Other: "#### Next Event:\n"},
+
+	"nudge.done":          {Other: "Nudged %s."},
+	"nudge.none":          {Other: "Nobody could be nudged."},
+	"nudge.not_connected": {Other: " Not connected to Google Calendar in Mattermost: %s."},
+	"nudge.organizer":     {Other: "The organizer"},
+	"nudge.waiting":       {Other: "**_%s is waiting for your response:_**\n"},
+
+	"ooo.backup": {Other: "\nFor anything urgent, please contact @%s."},
+	"ooo.reply":  {Other: ":palm_tree: @%s is out of office until %s."},
+
+	"reminder.action.failed":      {Other: "Unable to update your reminders. Error: %v"},
+	"reminder.all_day":            {Other: "**_All-day event today:_**"},
+	"reminder.join":               {Other: "Join now"},
+	"reminder.minutes":            {One: "**_%d minute until this event:_**", Other: "**_%d minutes until this event:_**"},
+	"reminder.mute":               {Other: "Mute this event"},
+	"reminder.muted":              {Other: "You won't get reminders or updates about _%s_ anymore."},
+	"reminder.snooze":             {Other: "Snooze %dm"},
+	"reminder.snooze_until_start": {Other: "Snooze until start"},
+	"reminder.snoozed":            {Other: "**_Snoozed reminder:_**"},
+	"reminder.snoozed.until":      {Other: "I'll remind you again at %s."},
+
+	"response.maybe":            {Other: "Maybe"},
+	"response.no":               {Other: "No"},
+	"response.status.accepted":  {Other: "%s accepted"},
+	"response.status.declined":  {Other: "%s declined"},
+	"response.status.pending":   {Other: "%s has not responded yet"},
+	"response.status.tentative": {Other: "%s tentatively accepted"},
+	"response.yes":              {Other: "Yes"},
+
+	"responses.accepted":  {Other: "Accepted"},
+	"responses.declined":  {Other: "Declined"},
+	"responses.failed":    {Other: "Unable to post the responses"},
+	"responses.maybe":     {Other: "Maybe"},
+	"responses.no_guests": {Other: "This event has no guests."},
+	"responses.nudge":     {Other: "Nudge pending"},
+	"responses.pending":   {Other: "Awaiting response"},
+	"responses.title":     {Other: "#### Responses to [%s](%s):\n"},
+
+	"search.empty":          {Other: "It seems that no events match your search."},
+	"search.from_after_to":  {Other: "--from date must be before --to date"},
+	"search.invalid":        {Other: "Invalid search, please search again"},
+	"search.invalid_from":   {Other: "invalid --from date format, please use YYYY-MM-DD"},
+	"search.invalid_to":     {Other: "invalid --to date format, please use YYYY-MM-DD"},
+	"search.loading":        {Other: "Searching your events..."},
+	"search.missing_text":   {Other: "missing text to search"},
+	"search.missing_value":  {Other: "missing value of %s"},
+	"search.more":           {Other: "More"},
+	"search.no_more":        {Other: "There are no more results."},
+	"search.title":          {Other: "#### Search results for \"%s\" (%d-%d of %d):\n"},
+	"search.title.attendee": {Other: "#### Events with %s (%d-%d of %d):\n"},
+
+	"settings.all_day_reminder":           {Other: "All-day event reminder time"},
+	"settings.all_day_reminder.help":      {Other: "Time of day to remind you of all-day events, in 24 hour HH:MM format"},
+	"settings.allow_notify":               {Other: "Allow notifications"},
+	"settings.delivery.channel":           {Other: "~%s (%s)"},
+	"settings.delivery.dm":                {Other: "Direct message from the bot"},
+	"settings.delivery.ephemeral":         {Other: "Only visible to me, not kept"},
+	"settings.delivery.help":              {Other: "Private channels you created are listed too, the bot must be a member of the channel"},
+	"settings.digest":                     {Other: "Update digest"},
+	"settings.digest.help":                {Other: "Batch invitations and updates in a digest instead of sending them as they come"},
+	"settings.digest.hourly":              {Other: "Every hour"},
+	"settings.digest.off":                 {Other: "Off"},
+	"settings.digest.twice_daily":         {Other: "Twice a day, at 9:00 and 17:00"},
+	"settings.digest_delivery":            {Other: "Send digests to"},
+	"settings.invalid.backup_contact":     {Other: "`OOOBackupContact username %s not found`"},
+	"settings.invalid.delivery_bot":       {Other: "`Add the calendar bot to ~%s so it can post there`"},
+	"settings.invalid.delivery_channel":   {Other: "`Notifications can only go to a private channel you created and are a member of`"},
+	"settings.invalid.quiet_hours":        {Other: "`%s is not a time in HH:MM format, set both quiet hours or leave both empty`"},
+	"settings.invalid.quiet_hours_same":   {Other: "`QuietHoursStart and QuietHoursEnd must be different`"},
+	"settings.invalid.reminder_time":      {Other: "`AllDayReminderTime is not a time in HH:MM format`"},
+	"settings.invalid.time_before":        {Other: "`TimeNotiBeforeEvent is not a number`"},
+	"settings.invalid.time_before_range":  {Other: "`TimeNotiBeforeEvent is not in range %d - %d`"},
+	"settings.invite_delivery":            {Other: "Send invitations to"},
+	"settings.layout":                     {Other: "Calendar updates"},
+	"settings.layout.combined":            {Other: "One combined message"},
+	"settings.layout.help":                {Other: "How to tell you about several calendar changes at once"},
+	"settings.layout.threads":             {Other: "One message per event thread"},
+	"settings.notify_decline":             {Other: "Notify when guests decline"},
+	"settings.notify_decline.placeholder": {Other: "Tell me when a guest declines an event I organize"},
+	"settings.ooo_backup":                 {Other: "Out of office backup contact"},
+	"settings.ooo_backup.help":            {Other: "Who to contact instead while you are out of office"},
+	"settings.ooo_reply":                  {Other: "Out of office auto-reply"},
+	"settings.ooo_reply.placeholder":      {Other: "Tell people messaging me when I am out of office"},
+	"settings.quiet_end":                  {Other: "Quiet hours end"},
+	"settings.quiet_end.help":             {Other: "End of the quiet hours, in 24 hour HH:MM format. Leave both empty to turn them off"},
+	"settings.quiet_start":                {Other: "Quiet hours start"},
+	"settings.quiet_start.help":           {Other: "From this time, calendar updates wait until the quiet hours end, in 24 hour HH:MM format. Reminders and changes to events starting soon are still sent"},
+	"settings.reminder_delivery":          {Other: "Send reminders to"},
+	"settings.time_before":                {Other: "Time notify before event"},
+	"settings.time_before.help":           {Other: "This is the time before event to notify. It must be an positive integer in minute between 0 and 40320"},
+	"settings.title":                      {Other: "Settings"},
+	"settings.update_delivery":            {Other: "Send event updates to"},
+	"settings.updated":                    {Other: "Successfully updated settings"},
+
+	"summary.empty":          {Other: "It seems that you don't have any events happening."},
+	"summary.invalid_date":   {Other: "Invalid date format, please use YYYY-MM-DD"},
+	"summary.loading":        {Other: "Getting your summary..."},
+	"summary.title.date":     {Other: "#### %s Schedule:\n"},
+	"summary.title.today":    {Other: "#### Today's Schedule:\n"},
+	"summary.title.tomorrow": {Other: "#### Tomorrow's Schedule:\n"},
+
+	"sync.failed": {Other: "Your Google Calendar couldn't be synced: %s\nIf you revoked the access or changed your password, please connect again with `/calendar connect`."},
+
+	"team.back":                  {Other: "Back %s"},
+	"team.busy":                  {Other: ":red_circle: In a meeting"},
+	"team.channel_not_found":     {Other: "channel %s not found"},
+	"team.digest.add_bot_failed": {Other: "unable to add the bot to this channel"},
+	"team.digest.already":        {Other: "This channel already gets the daily \"who's out\" post."},
+	"team.digest.not_subscribed": {Other: "This channel doesn't get the daily \"who's out\" post."},
+	"team.digest.subscribed":     {Other: "This channel will get who's out this week every weekday at %s."},
+	"team.digest.unsubscribed":   {Other: "This channel won't get the daily \"who's out\" post anymore."},
+	"team.free":                  {Other: ":large_green_circle: Free"},
+	"team.free_rest":             {Other: "For the rest of the day"},
+	"team.group_not_found":       {Other: "group %s not found"},
+	"team.header":                {Other: "| Member | Status | |\n|:-------|:-------|:--|\n"},
+	"team.next":                  {Other: "Next meeting at %s"},
+	"team.nobody":                {Other: "Nobody in %s has connected their Google Calendar."},
+	"team.out":                   {Other: ":palm_tree: Out of office"},
+	"team.this_channel":          {Other: "this channel"},
+	"team.title":                 {Other: "#### Availability of %s (%s):\n"},
+	"team.until":                 {Other: "Until %s"},
+	"team.whos_out.member":       {Other: "- @%s: from %s, back %s\n"},
+	"team.whos_out.none":         {Other: "Everyone is in this week.\n"},
+	"team.whos_out.title":        {Other: "#### :palm_tree: Who's out this week:\n"},
+
+	"thread.cancelled": {Other: "**_This event has been cancelled:_**\n\n**~~[%s](%s)~~**\n"},
+	"thread.event":     {Other: "**_Event:_**\n"},
+}
+
+// englishHelp is the help of the command, the | are replaced by backquotes
+const englishHelp = `* |/calendar connect| - Connect your Google Calendar with your Mattermost account
+
+---
+
+* |/calendar create| Create a event with a title, start date-time, end date-time, and attendees (attendees are optional) - This command will create Google Meeting automatically
+	* |Title| can be any title you like for the event.
+	* |Start DateTime| This is the time the event starts. It should be a date and time in the format of YYYY-MM-DD@HH:MM in 24 hour time format.
+		* Example: 2022-01-01@12:00
+	* |End DateTime| This is the time the event ends. It should be a date and time in the format of YYYY-MM-DD@HH:MM in 24 hour time format.
+		* Example: 2022-01-01@13:00
+	* |Attendees - Optional| This is a list of username or email addresses of the people you want to invite to the event. You have to type within square brackets "[ ]" and separate each username or email address with space.
+		* Example: [@exampleuser-1, @exampleuser-2 example@gmail.com ...]
+	* |--force - Optional| The event is not created when it overlaps events you are going to, add this at the end to create it anyway.
+	**Full command Example:** => | /calendar create 'my-meeting' 2022-01-01@12:00 2022-01-01@13:00 [exampleuser-1 exampleuser-2 example@gmail.com] |
+
+---
+
+* |/calendar edit [event]| - Edit one of your upcoming events. Pick the event from the list and change it in the dialog.
+	* |event| is one of the upcoming events you organize, the list is suggested while typing.
+	* |Send updates| lets you choose whether the guests get an email about your changes.
+
+---
+
+* |/calendar summary [date]| - Get a break down of a particular date.
+	* |date| can be word 'today' or 'tmr' or specific date in YYYY-MM-DD format.
+		* Example: [@exampleuser-1, @exampleuser-2, example@gmail.com ...]
+	**Full command Example:** => | /calendar summary today |  | /calendar summary tmr |  | /calendar summary 2022-01-01 |
+
+---
+
+* |/calendar agenda [range] [compact]| - Get your agenda of a week or of a custom range, grouped by day.
+	* |range| can be words 'this week' or 'next week' or specific dates in YYYY-MM-DD..YYYY-MM-DD format.
+	* |compact| shows one line per event instead of a table, useful for busy calendars.
+	**Full command Example:** => | /calendar agenda this week |  | /calendar agenda next week compact |  | /calendar agenda 2022-01-01..2022-01-14 |
+
+---
+
+* |/calendar conflicts [range]| - List your events overlapping each other.
+	* |range| same as agenda, can be words 'this week' or 'next week' or specific dates in YYYY-MM-DD..YYYY-MM-DD format.
+	**Full command Example:** => | /calendar conflicts |  | /calendar conflicts next week |
+
+---
+
+* |/calendar search <text> [--from date] [--to date] [--attendee @user]| - Search your events.
+	* |text| is looked for in the title, description, location and guests of the events.
+	* |--from date - Optional| Search events from this date in YYYY-MM-DD format, today by default.
+	* |--to date - Optional| Search events until this date in YYYY-MM-DD format, 90 days after the start by default.
+	* |--attendee @user - Optional| Only search events this username or email address is invited to.
+	**Full command Example:** => | /calendar search planning --from 2022-01-01 --to 2022-01-31 --attendee @exampleuser-1 |
+
+---
+
+* |/calendar responses <event>| - See who accepted, declined, tentatively accepted or has not answered an event you organize.
+	* |event| one of your upcoming events, pick it from the autocomplete list.
+	* |Nudge pending| button sends the guests who have not answered and use this plugin a reminder with the buttons to answer.
+
+---
+
+* |/calendar team [~channel|@group]| - See who is free, in a meeting or out of office right now.
+	* |~channel|@group - Optional| The channel or the group to look at, this channel by default. Only the members who connected their Google Calendar are shown, and only the meetings you are invited to are named.
+	* |/calendar team subscribe| Post who's out this week in this channel every weekday morning, |/calendar team unsubscribe| to stop.
+	**Full command Example:** => | /calendar team |  | /calendar team ~town-square |  | /calendar team @developers |
+
+---
+
+* |/calendar admin| - Operate the plugin, only for system admins. Use |/calendar admin help| to see the admin commands.
+
+---
+
+* |/calendar settings| - User settings, you can see and change your settings.
+	* |You can select these to set configuration|
+		* |Allow notifications| Allow calendar to notify you in the channel.
+		* |Time notify before event| This is the time before event to notify. It must be an positive integer in minute (This will effect when you allow to notify)
+		* |All-day event reminder time| This is the time of day to notify about all-day events, in 24 hour HH:MM format.
+		* |Notify when guests decline| Tell you when a guest declines an event you organize.
+		* |Out of office auto-reply| When you have an out of office event in Google Calendar, tell the people sending you a direct message or mentioning you, at most once every few hours.
+		* |Out of office backup contact| The username people should contact while you are out of office.
+		* |Calendar updates| Get the changes found by one sync in one combined message, or one message in the thread of each event.
+
+---
+
+* |/calendar next| - Get the next event of today
+
+--- 
+
+* |/calendar disconnect| - Disconnect Google Calendar from your Mattermost account
+
+---
+`
@@ -0,0 +1,164 @@
+// Package i18n holds the message catalogs of the bot and formats dates in the language of the
+// user. Messages are fmt formats, translations reorder their arguments with explicit indexes like
+// %[2]s when the grammar needs it.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used for the users whose locale has no catalog, and for the messages missing
+// from a catalog
+const DefaultLocale = "en"
+
+// Message is a translation. One is only used by the languages that have a singular, Other is used
+// otherwise and for every message that doesn't depend on a count
+type Message struct {
+	One   string
+	Other string
+}
+
+// locale is everything needed to speak a language
+type locale struct {
+	messages      map[string]Message
+	weekdays      [7]string
+	shortWeekdays [7]string
+	months        [12]string
+	shortMonths   [12]string
+	am, pm        string
+
+	// isOne tells whether the singular form is used for n
+	isOne func(n int) bool
+
+	// date prints a full date, shortDate a compact date with the weekday and clock12 a time on the
+	// 12-hour clock
+	date      func(l *Localizer, t time.Time) string
+	shortDate func(l *Localizer, t time.Time) string
+	clock12   func(l *Localizer, t time.Time) string
+}
+
+var locales = map[string]*locale{
+	"en": english,
+	"th": thai,
+	"ja": japanese,
+}
+
+// Locales returns the supported locales
+func Locales() []string {
+	return []string{"en", "th", "ja"}
+}
+
+// Localizer translates the messages and dates in one language
+type Localizer struct {
+	tag    string
+	locale *locale
+}
+
+// NewLocalizer returns the localizer of a Mattermost locale like "ja" or "pt-BR", the default
+// locale is used when the language isn't supported
+func NewLocalizer(tag string) *Localizer {
+	tag = strings.ToLower(tag)
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if l, ok := locales[tag]; ok {
+		return &Localizer{tag: tag, locale: l}
+	}
+	return &Localizer{tag: DefaultLocale, locale: locales[DefaultLocale]}
+}
+
+// Locale returns the locale the messages are translated to
+func (l *Localizer) Locale() string {
+	return l.tag
+}
+
+// T translates the message, formatted with args
+func (l *Localizer) T(id string, args ...interface{}) string {
+	return l.format(l.message(id).Other, args)
+}
+
+// N translates the message in the plural form matching count. count is the first argument of the
+// format, followed by args
+func (l *Localizer) N(id string, count int, args ...interface{}) string {
+	message := l.message(id)
+	text := message.Other
+	if message.One != "" && l.locale.isOne(count) {
+		text = message.One
+	}
+	return l.format(text, append([]interface{}{count}, args...))
+}
+
+func (l *Localizer) message(id string) Message {
+	if message, ok := l.locale.messages[id]; ok {
+		return message
+	}
+	if message, ok := locales[DefaultLocale].messages[id]; ok {
+		return message
+	}
+	return Message{Other: id}
+}
+
+func (l *Localizer) format(text string, args []interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Weekday returns the name of the day, like Monday
+func (l *Localizer) Weekday(d time.Weekday) string {
+	return l.locale.weekdays[d]
+}
+
+// ShortWeekday returns the abbreviated name of the day, like Mon
+func (l *Localizer) ShortWeekday(d time.Weekday) string {
+	return l.locale.shortWeekdays[d]
+}
+
+// Month returns the name of the month, like January
+func (l *Localizer) Month(m time.Month) string {
+	return l.locale.months[m-1]
+}
+
+// ShortMonth returns the abbreviated name of the month, like Jan
+func (l *Localizer) ShortMonth(m time.Month) string {
+	return l.locale.shortMonths[m-1]
+}
+
+// Date prints the date of t with the weekday, like Monday, January 2, 2006
+func (l *Localizer) Date(t time.Time) string {
+	return l.locale.date(l, t)
+}
+
+// ShortDate prints the date of t without the year, like Mon 2 Jan
+func (l *Localizer) ShortDate(t time.Time) string {
+	return l.locale.shortDate(l, t)
+}
+
+// Clock prints the time of t without the zone, on the 24-hour clock when military is set
+func (l *Localizer) Clock(t time.Time, military bool) string {
+	if military {
+		return t.Format("15:04")
+	}
+	return l.locale.clock12(l, t)
+}
+
+// meridiem returns the localized AM or PM of t
+func (l *Localizer) meridiem(t time.Time) string {
+	if t.Hour() < 12 {
+		return l.locale.am
+	}
+	return l.locale.pm
+}
+
+// isOneEnglish is the plural rule of the languages with a singular for 1 only
+func isOneEnglish(n int) bool {
+	return n == 1
+}
+
+// isOneNever is the plural rule of the languages without plural forms
+func isOneNever(n int) bool {
+	return false
+}
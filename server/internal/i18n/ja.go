@@ -0,0 +1,436 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+var japanese = &locale{
+	messages:      japaneseMessages,
+	weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+	shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+	months:        [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+	shortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+	am:            "午前",
+	pm:            "午後",
+	isOne:         isOneNever,
+	date: func(l *Localizer, t time.Time) string {
+		return fmt.Sprintf("%d年%d月%d日(%s)", t.Year(), t.Month(), t.Day(), l.ShortWeekday(t.Weekday()))
+	},
+	shortDate: func(l *Localizer, t time.Time) string {
+		return fmt.Sprintf("%d月%d日(%s)", t.Month(), t.Day(), l.ShortWeekday(t.Weekday()))
+	},
+	clock12: func(l *Localizer, t time.Time) string {
+		return l.meridiem(t) + t.Format("3:04")
+	},
+}
+
+var japaneseMessages = map[string]Message{
+	// autocomplete of the command
+	"autocomplete.calendar":            {Other: "利用できるコマンド: connect, create, edit, next, summary, agenda, conflicts, search, responses, team, settings, disconnect, help"},
+	"autocomplete.connect":             {Other: "Google カレンダーを Mattermost アカウントと連携します"},
+	"autocomplete.create":              {Other: "タイトル、開始日時、終了日時、参加者を指定して予定を作成します"},
+	"autocomplete.create.title":        {Other: "作成する予定のタイトル。シングルクォートで囲んでください。例: 'my-meeting'"},
+	"autocomplete.create.start":        {Other: "予定の開始日時。YYYY-MM-DD@HH:MM 形式 (2022-01-01@12:00)。"},
+	"autocomplete.create.end":          {Other: "予定の終了日時。YYYY-MM-DD@HH:MM 形式 (2022-01-01@13:00)。"},
+	"autocomplete.create.attendees":    {Other: "招待する人のユーザー名またはメールアドレスの一覧。角括弧 `[ ]` の中に、スペースで区切って入力してください。\n 例: [example1 example2 example@gmail.com]"},
+	"autocomplete.create.force":        {Other: "参加予定の予定と重複していても予定を作成します"},
+	"autocomplete.edit":                {Other: "今後の予定を編集します"},
+	"autocomplete.event":               {Other: "自分が主催する今後の予定"},
+	"autocomplete.next":                {Other: "今日の次の予定を表示します"},
+	"autocomplete.summary":             {Other: "指定した日の予定の概要を表示します"},
+	"autocomplete.summary.date":        {Other: "日付は [today]、[tmr]、または YYYY-MM-DD 形式 (2022-01-01) で指定します"},
+	"autocomplete.agenda":              {Other: "1週間または指定した期間の予定を表示します"},
+	"autocomplete.agenda.range":        {Other: "期間は [this week]、[next week]、または YYYY-MM-DD..YYYY-MM-DD 形式で指定します。[compact] を付けると簡潔に表示します"},
+	"autocomplete.conflicts":           {Other: "重複している予定を一覧表示します"},
+	"autocomplete.conflicts.range":     {Other: "期間は [this week]、[next week]、または YYYY-MM-DD..YYYY-MM-DD 形式で指定します"},
+	"autocomplete.search":              {Other: "予定を検索します"},
+	"autocomplete.search.text":         {Other: "予定のタイトル、説明、場所、ゲストから検索する文字列"},
+	"autocomplete.search.from":         {Other: "この日付 (YYYY-MM-DD 形式) 以降の予定を検索します"},
+	"autocomplete.search.to":           {Other: "この日付 (YYYY-MM-DD 形式) までの予定を検索します"},
+	"autocomplete.search.attendee":     {Other: "このユーザー名またはメールアドレスが招待されている予定のみを検索します"},
+	"autocomplete.responses":           {Other: "自分が主催する予定への返信を確認し、未返信の人に催促します"},
+	"autocomplete.team":                {Other: "空いている人、会議中の人、不在の人を確認します"},
+	"autocomplete.team.target":         {Other: "確認するチャンネルまたはグループ。省略するとこのチャンネル。[subscribe] で平日毎朝、今週の不在者をこのチャンネルに投稿します"},
+	"autocomplete.admin":               {Other: "プラグインを管理します (システム管理者のみ)"},
+	"autocomplete.admin.stats":         {Other: "連携しているユーザー、期限が近い監視チャンネル、最近の同期エラー"},
+	"autocomplete.admin.user":          {Other: "ユーザーの連携状況、最終同期、トークンの状態"},
+	"autocomplete.admin.username":      {Other: "ユーザーのユーザー名"},
+	"autocomplete.admin.resync":        {Other: "同期済みの予定を破棄して、もう一度すべて同期します"},
+	"autocomplete.admin.resync.target": {Other: "ユーザーのユーザー名、または all"},
+	"autocomplete.admin.disconnect":    {Other: "ユーザーの Google カレンダーの連携を解除します"},
+	"autocomplete.admin.renew_watches": {Other: "全ユーザーのプッシュ通知チャンネルを設定し直します"},
+	"autocomplete.settings":            {Other: "ユーザー設定を確認、変更します。"},
+	"autocomplete.disconnect":          {Other: "Google カレンダーと Mattermost アカウントの連携を解除します。"},
+	"autocomplete.help":                {Other: "使い方を表示します"},
+
+	"agenda.continued":             {Other: "%s（続き）"},
+	"agenda.loading":               {Other: "予定を取得しています..."},
+	"agenda.range.dates":           {Other: "%s〜%s"},
+	"agenda.range.invalid":         {Other: "期間が正しくありません。[this week]、[next week] または YYYY-MM-DD..YYYY-MM-DD の形式で指定してください"},
+	"agenda.range.invalid_end":     {Other: "終了日の形式が正しくありません。YYYY-MM-DD の形式で指定してください"},
+	"agenda.range.invalid_start":   {Other: "開始日の形式が正しくありません。YYYY-MM-DD の形式で指定してください"},
+	"agenda.range.next_week":       {Other: "来週"},
+	"agenda.range.start_after_end": {Other: "開始日は終了日より前にしてください"},
+	"agenda.range.this_week":       {Other: "今週"},
+	"agenda.range.too_long":        {Other: "期間は%d日以内で指定してください"},
+	"agenda.response.links":        {Other: "[はい](%s) / [いいえ](%s) / [未定](%s)"},
+	"agenda.table_header":          {Other: "| 時間 | タイトル | 場所 | 参加? |"},
+	"agenda.time.all_day":          {Other: "終日"},
+	"agenda.time.all_day_of":       {Other: "終日、%d日目（全%d日）"},
+	"agenda.time.day_of":           {Other: "%d日目（全%d日）"},
+	"agenda.time.from":             {Other: "%s から、%d日目（全%d日）"},
+	"agenda.time.until":            {Other: "%s まで、%d日目（全%d日）"},
+	"agenda.title":                 {Other: "#### %sの予定:\n"},
+
+	"card.delete":        {Other: "削除"},
+	"card.guests":        {Other: "ゲスト"},
+	"card.guests.counts": {Other: "はい %d、いいえ %d、未定 %d、未返信 %d"},
+	"card.join":          {Other: "参加"},
+	"card.join.link":     {Other: "[会議に参加](%s)"},
+	"card.organizer":     {Other: "主催者"},
+	"card.when":          {Other: "日時"},
+	"card.where":         {Other: "場所"},
+
+	"changes.cancelled":         {Other: "**_予定がキャンセルされました:_**\n\n**~~[%s](%s)~~**\n**日時**: ~~%s~~\n"},
+	"changes.declined":          {Other: "**_ゲストが辞退しました:_**\n\n**[%s](%s)**\n"},
+	"changes.invited":           {Other: "**_予定に招待されました:_**\n"},
+	"changes.section.added":     {Other: "新しい招待"},
+	"changes.section.cancelled": {Other: "キャンセル"},
+	"changes.section.responses": {Other: "返信"},
+	"changes.section.updated":   {Other: "更新"},
+	"changes.title":             {Other: "#### カレンダーの更新 (%d):\n"},
+
+	"command.description": {Other: "Google カレンダーとの連携"},
+	"command.unknown":     {Other: "不明なコマンドです: `%v`"},
+
+	"conflicts.decline":       {Other: "  [%s を辞退](%s) | [別の時間を提案](%s)\n"},
+	"conflicts.double_booked": {Other: "**ダブルブッキング?**: [辞退](%s) | [別の時間を提案](%s)\n"},
+	"conflicts.empty":         {Other: "重複している予定はありません。"},
+	"conflicts.loading":       {Other: "重複している予定を探しています..."},
+	"conflicts.pair":          {Other: "- [%s](%s) %s\n  と重複: [%s](%s) %s\n"},
+	"conflicts.title":         {Other: "#### %sの重複している予定:\n"},
+	"conflicts.warning":       {Other: "\n**:warning: 重複している予定:**\n"},
+
+	"connect.link":    {Other: "[ここをクリックして Google カレンダーを連携してください。](%s)"},
+	"connect.synced":  {Other: "Google カレンダーの通知が Mattermost と同期されました!"},
+	"connect.welcome": {Other: "#### Mattermost Google カレンダープラグインへようこそ!\nMattermost アカウントと Google カレンダーの連携が完了しました。\n使い方は **/calendar help** で確認できます。"},
+
+	"date.today":    {Other: "今日"},
+	"date.tomorrow": {Other: "明日"},
+
+	"dialog.save": {Other: "保存"},
+
+	"digest.title": {Other: "#### カレンダーのダイジェスト (%d件):\n"},
+
+	"disconnect.by_admin":      {Other: "システム管理者があなたの Google カレンダーの接続を解除しました。`/calendar connect` で再接続できます。"},
+	"disconnect.confirm":       {Other: "確認"},
+	"disconnect.confirm.title": {Other: "接続を解除しますか？"},
+	"disconnect.failed":        {Other: "カレンダーの連携を解除できませんでした"},
+	"disconnect.success":       {Other: "カレンダーの連携を解除しました"},
+
+	"error.not_connected":           {Other: "Google カレンダーを連携してください。コマンド => `/calendar connect`"},
+	"error.not_found":               {Other: "見つかりませんでした。削除された可能性があります。"},
+	"error.not_organizer":           {Other: "変更できるのは自分が作成した予定のみです。"},
+	"error.not_organizer.delete":    {Other: "削除できるのは自分が作成した予定のみです。"},
+	"error.not_organizer.edit":      {Other: "編集できるのは自分が作成した予定のみです。"},
+	"error.not_organizer.nudge":     {Other: "ゲストに催促できるのは自分が作成した予定のみです。"},
+	"error.not_organizer.responses": {Other: "返信を確認できるのは自分が作成した予定のみです。"},
+	"error.rate_limited":            {Other: "Google カレンダーへのリクエストが多すぎます。数分後にもう一度お試しください。"},
+	"error.token_revoked":           {Other: "Google がカレンダーへのアクセスを受け付けなくなりました。`/calendar connect` でもう一度連携してください。"},
+	"error.unknown":                 {Other: "問題が発生しました: %s"},
+
+	"event.create.command":               {Other: "直前のコマンド: `%s`"},
+	"event.create.conflict_check":        {Other: "カレンダーの重複を確認できませんでした。エラー: %v"},
+	"event.create.conflicts":             {Other: "予定は作成されませんでした。"},
+	"event.create.failed":                {Other: "予定を作成できませんでした。エラー: %v"},
+	"event.create.force":                 {Other: "それでも作成するにはコマンドの最後に `%s` を付けてください。"},
+	"event.create.invalid_end":           {Other: "終了日時の形式が正しくありません: %v"},
+	"event.create.invalid_start":         {Other: "開始日時の形式が正しくありません: %v"},
+	"event.create.missing_end":           {Other: "終了日時がありません"},
+	"event.create.missing_start":         {Other: "開始日時がありません"},
+	"event.create.missing_title":         {Other: "タイトルがありません"},
+	"event.create.success":               {Other: "%[3]s の予定 _[%[1]s](%[2]s)_ を作成しました。"},
+	"event.create.title_quotes":          {Other: "タイトルはシングルクォートで囲んでください"},
+	"event.delete":                       {Other: "[予定を削除](%s)\n"},
+	"event.delete.failed":                {Other: "予定を削除できませんでした。エラー: %v"},
+	"event.delete.success":               {Other: "予定 _%s_ を削除しました。"},
+	"event.description":                  {Other: "**説明**: %s\n"},
+	"event.edit.failed":                  {Other: "予定を編集できませんでした。エラー: %v"},
+	"event.edit.field.attendees":         {Other: "参加者"},
+	"event.edit.field.attendees.help":    {Other: "ユーザー名またはメールアドレスをスペース区切りで指定します。例: @exampleuser-1 example@gmail.com"},
+	"event.edit.field.date.help":         {Other: "終日の予定は YYYY-MM-DD で指定してください"},
+	"event.edit.field.description":       {Other: "説明"},
+	"event.edit.field.end":               {Other: "終了日時"},
+	"event.edit.field.location":          {Other: "場所"},
+	"event.edit.field.send_updates":      {Other: "更新の通知"},
+	"event.edit.field.start":             {Other: "開始日時"},
+	"event.edit.field.title":             {Other: "タイトル"},
+	"event.edit.field.title.placeholder": {Other: "予定のタイトル"},
+	"event.edit.invalid_end":             {Other: "終了日時の形式が正しくありません: [%v]"},
+	"event.edit.invalid_start":           {Other: "開始日時の形式が正しくありません: [%v]"},
+	"event.edit.mixed_all_day":           {Other: "終日の予定では開始と終了の両方を日付で、それ以外は両方を日時で指定してください"},
+	"event.edit.send_updates.all":        {Other: "すべてのゲストに送信"},
+	"event.edit.send_updates.external":   {Other: "組織外のゲストに送信"},
+	"event.edit.send_updates.none":       {Other: "送信しない"},
+	"event.edit.start_after_end":         {Other: "開始日時は終了日時より前にしてください"},
+	"event.edit.title":                   {Other: "予定を編集"},
+	"event.edit.update_failed":           {Other: "予定を更新できませんでした。エラー: %v"},
+	"event.going":                        {Other: "**参加?**: %s"},
+	"event.going.links":                  {Other: "**参加?**: [はい](%s) | [いいえ](%s) | [未定](%s)"},
+	"event.guests":                       {Other: "**ゲスト**: はい %d、いいえ %d、未定 %d、未返信 %d\n"},
+	"event.guests.added":                 {Other: "**追加されたゲスト**: %s\n"},
+	"event.guests.external":              {Other: "社外"},
+	"event.guests.more":                  {Other: "他 %d 人"},
+	"event.guests.removed":               {Other: "**削除されたゲスト**: ~~%s~~\n"},
+	"event.guests.team":                  {Other: "チーム"},
+	"event.meet":                         {Other: "**Meet**: %s\n"},
+	"event.meet.added":                   {Other: "**Meet**: %s（追加）\n"},
+	"event.meet.removed":                 {Other: "**Meet**: ~~%s~~（削除）\n"},
+	"event.missing":                      {Other: "予定が指定されていません。今後の予定から選んでください"},
+	"event.not_found":                    {Other: "予定 `%s` が見つかりません"},
+	"event.organizer":                    {Other: "**主催者**: %s\n"},
+	"event.repeats":                      {Other: "**繰り返し**: %s\n"},
+	"event.repeats.none":                 {Other: "繰り返しなし"},
+	"event.respond.failed":               {Other: "予定に返信できませんでした。エラー: %v"},
+	"event.respond.success":              {Other: "予定 _%s_ への返信を更新しました。"},
+	"event.respond.update_failed":        {Other: "エラー: 予定 _%s_ への返信を更新できませんでした。"},
+	"event.status":                       {Other: "**ステータス**: %s\n"},
+	"event.status.cancelled":             {Other: "キャンセル"},
+	"event.status.confirmed":             {Other: "確定"},
+	"event.status.tentative":             {Other: "仮"},
+	"event.updated.title":                {Other: "**_予定が更新されました:_**\n"},
+	"event.when":                         {Other: "**日時**: %s\n"},
+	"event.when.all_day":                 {Other: "%s 終日"},
+	"event.when.all_days":                {Other: "%s〜%s（終日）"},
+	"event.when.day_of":                  {Other: "（%d日目／全%d日）"},
+	"event.when.time":                    {Other: "%s %s〜%s"},
+	"event.when.times":                   {Other: "%s %s〜%s %s"},
+	"event.when.zone":                    {Other: "（%[3]s では %[1]s〜%[2]s）"},
+	"event.where":                        {Other: "**場所**: %s\n"},
+	"events.fetch_failed":                {Other: "予定を取得できませんでした"},
+
+	"help":       {Other: japaneseHelp},
+	"help.title": {Other: "###### Mattermost Google カレンダープラグイン - スラッシュコマンドのヘルプ\n"},
+
+	"next.empty":   {Other: "今日はこれ以上予定がありません。"},
+	"next.loading": {Other: "次の予定を取得しています..."},
+	"next.title":   {Other: "#### 次の予定:\n"},
+
+	"nudge.done":          {Other: "%s に催促しました。"},
+	"nudge.none":          {Other: "催促できる人がいませんでした。"},
+	"nudge.not_connected": {Other: " Mattermost で Google カレンダーに接続していない人: %s。"},
+	"nudge.organizer":     {Other: "主催者"},
+	"nudge.waiting":       {Other: "**_%s があなたの返信を待っています:_**\n"},
+
+	"ooo.backup": {Other: "\nお急ぎの場合は @%s までご連絡ください。"},
+	"ooo.reply":  {Other: ":palm_tree: @%s は %s まで不在です。"},
+
+	"reminder.action.failed":      {Other: "リマインダーを更新できませんでした。エラー: %v"},
+	"reminder.all_day":            {Other: "**_本日の終日の予定:_**"},
+	"reminder.join":               {Other: "今すぐ参加"},
+	"reminder.minutes":            {Other: "**_この予定まであと%d分:_**"},
+	"reminder.mute":               {Other: "この予定をミュート"},
+	"reminder.muted":              {Other: "_%s_ のリマインダーと更新通知を停止しました。"},
+	"reminder.snooze":             {Other: "%d分後に再通知"},
+	"reminder.snooze_until_start": {Other: "開始時に再通知"},
+	"reminder.snoozed":            {Other: "**_再通知:_**"},
+	"reminder.snoozed.until":      {Other: "%s に再度お知らせします。"},
+
+	"response.maybe":            {Other: "未定"},
+	"response.no":               {Other: "いいえ"},
+	"response.status.accepted":  {Other: "%s が承諾しました"},
+	"response.status.declined":  {Other: "%s が辞退しました"},
+	"response.status.pending":   {Other: "%s はまだ返信していません"},
+	"response.status.tentative": {Other: "%s が仮承諾しました"},
+	"response.yes":              {Other: "はい"},
+
+	"responses.accepted":  {Other: "承諾"},
+	"responses.declined":  {Other: "辞退"},
+	"responses.failed":    {Other: "返信を投稿できませんでした"},
+	"responses.maybe":     {Other: "未定"},
+	"responses.no_guests": {Other: "この予定にはゲストがいません。"},
+	"responses.nudge":     {Other: "未返信の人に催促"},
+	"responses.pending":   {Other: "返信待ち"},
+	"responses.title":     {Other: "#### [%s](%s) への返信:\n"},
+
+	"search.empty":          {Other: "検索に一致する予定はありません。"},
+	"search.from_after_to":  {Other: "--from の日付は --to より前にしてください"},
+	"search.invalid":        {Other: "検索条件が正しくありません。もう一度検索してください"},
+	"search.invalid_from":   {Other: "--from の日付の形式が正しくありません。YYYY-MM-DD で指定してください"},
+	"search.invalid_to":     {Other: "--to の日付の形式が正しくありません。YYYY-MM-DD で指定してください"},
+	"search.loading":        {Other: "予定を検索しています..."},
+	"search.missing_text":   {Other: "検索する文字列がありません"},
+	"search.missing_value":  {Other: "%s の値がありません"},
+	"search.more":           {Other: "もっと見る"},
+	"search.no_more":        {Other: "これ以上の結果はありません。"},
+	"search.title":          {Other: "#### 「%s」の検索結果（%d〜%d件目／全%d件）:\n"},
+	"search.title.attendee": {Other: "#### %s との予定（%d〜%d件目／全%d件）:\n"},
+
+	"settings.all_day_reminder":           {Other: "終日の予定の通知時刻"},
+	"settings.all_day_reminder.help":      {Other: "終日の予定を通知する時刻（24時間制 HH:MM）"},
+	"settings.allow_notify":               {Other: "通知を許可"},
+	"settings.delivery.channel":           {Other: "~%s（%s）"},
+	"settings.delivery.dm":                {Other: "ボットからのダイレクトメッセージ"},
+	"settings.delivery.ephemeral":         {Other: "自分だけに表示、保存しない"},
+	"settings.delivery.help":              {Other: "自分が作成したプライベートチャンネルも選べます。ボットがそのチャンネルのメンバーである必要があります"},
+	"settings.digest":                     {Other: "更新のダイジェスト"},
+	"settings.digest.help":                {Other: "招待と更新を届いた都度ではなくダイジェストにまとめて送る"},
+	"settings.digest.hourly":              {Other: "1時間ごと"},
+	"settings.digest.off":                 {Other: "オフ"},
+	"settings.digest.twice_daily":         {Other: "1日2回（9:00と17:00）"},
+	"settings.digest_delivery":            {Other: "ダイジェストの送信先"},
+	"settings.invalid.backup_contact":     {Other: "`OOOBackupContact` のユーザー %s が見つかりません"},
+	"settings.invalid.delivery_bot":       {Other: "~%s にカレンダーボットを追加して、投稿できるようにしてください"},
+	"settings.invalid.delivery_channel":   {Other: "通知の送信先には、自分が作成し参加しているプライベートチャンネルのみ指定できます"},
+	"settings.invalid.quiet_hours":        {Other: "`%s` は HH:MM 形式の時刻で指定してください。おやすみ時間は両方指定するか、両方空にしてください"},
+	"settings.invalid.quiet_hours_same":   {Other: "`QuietHoursStart` と `QuietHoursEnd` は異なる時刻にしてください"},
+	"settings.invalid.reminder_time":      {Other: "`AllDayReminderTime` は HH:MM 形式の時刻で指定してください"},
+	"settings.invalid.time_before":        {Other: "`TimeNotiBeforeEvent` は数値で指定してください"},
+	"settings.invalid.time_before_range":  {Other: "`TimeNotiBeforeEvent` は %d〜%d の範囲で指定してください"},
+	"settings.invite_delivery":            {Other: "招待の送信先"},
+	"settings.layout":                     {Other: "カレンダーの更新"},
+	"settings.layout.combined":            {Other: "1つのメッセージにまとめる"},
+	"settings.layout.help":                {Other: "複数の変更をまとめて知らせる方法"},
+	"settings.layout.threads":             {Other: "予定ごとのスレッドに投稿"},
+	"settings.notify_decline":             {Other: "ゲストが辞退したら通知"},
+	"settings.notify_decline.placeholder": {Other: "自分が主催する予定をゲストが辞退したら知らせる"},
+	"settings.ooo_backup":                 {Other: "不在時の代理連絡先"},
+	"settings.ooo_backup.help":            {Other: "不在中に代わりに連絡してほしい人"},
+	"settings.ooo_reply":                  {Other: "不在時の自動返信"},
+	"settings.ooo_reply.placeholder":      {Other: "不在中にメッセージをくれた人に知らせる"},
+	"settings.quiet_end":                  {Other: "通知停止の終了時刻"},
+	"settings.quiet_end.help":             {Other: "通知停止の終了時刻（24時間制 HH:MM）。両方を空にすると無効になります"},
+	"settings.quiet_start":                {Other: "通知停止の開始時刻"},
+	"settings.quiet_start.help":           {Other: "この時刻から通知停止の終了まで、カレンダーの更新を保留します（24時間制 HH:MM）。リマインダーとまもなく始まる予定の変更は送信されます"},
+	"settings.reminder_delivery":          {Other: "リマインダーの送信先"},
+	"settings.time_before":                {Other: "予定の何分前に通知"},
+	"settings.time_before.help":           {Other: "予定の何分前に通知するか。0〜40320 の整数（分）で指定してください"},
+	"settings.title":                      {Other: "設定"},
+	"settings.update_delivery":            {Other: "予定の更新の送信先"},
+	"settings.updated":                    {Other: "設定を更新しました"},
+
+	"summary.empty":          {Other: "予定はありません。"},
+	"summary.invalid_date":   {Other: "日付の形式が正しくありません。YYYY-MM-DD の形式で指定してください"},
+	"summary.loading":        {Other: "予定の概要を取得しています..."},
+	"summary.title.date":     {Other: "#### %sの予定:\n"},
+	"summary.title.today":    {Other: "#### 今日の予定:\n"},
+	"summary.title.tomorrow": {Other: "#### 明日の予定:\n"},
+
+	"sync.failed": {Other: "Google カレンダーを同期できませんでした: %s\nアクセスを取り消したかパスワードを変更した場合は、`/calendar connect` でもう一度連携してください。"},
+
+	"team.back":                  {Other: "%s に戻ります"},
+	"team.busy":                  {Other: ":red_circle: 会議中"},
+	"team.channel_not_found":     {Other: "チャンネル %s が見つかりません"},
+	"team.digest.add_bot_failed": {Other: "このチャンネルにボットを追加できませんでした"},
+	"team.digest.already":        {Other: "このチャンネルにはすでに毎日の「不在者」投稿が届きます。"},
+	"team.digest.not_subscribed": {Other: "このチャンネルには毎日の「不在者」投稿は届いていません。"},
+	"team.digest.subscribed":     {Other: "このチャンネルに平日の毎日 %s に今週の不在者を投稿します。"},
+	"team.digest.unsubscribed":   {Other: "このチャンネルへの毎日の「不在者」投稿を停止しました。"},
+	"team.free":                  {Other: ":large_green_circle: 空き"},
+	"team.free_rest":             {Other: "今日はこの後ずっと空いています"},
+	"team.group_not_found":       {Other: "グループ %s が見つかりません"},
+	"team.header":                {Other: "| メンバー | 状況 | |\n|:-------|:-------|:--|\n"},
+	"team.next":                  {Other: "次の会議は %s"},
+	"team.nobody":                {Other: "%s では誰も Google カレンダーに接続していません。"},
+	"team.out":                   {Other: ":palm_tree: 不在"},
+	"team.this_channel":          {Other: "このチャンネル"},
+	"team.title":                 {Other: "#### %s の空き状況（%s）:\n"},
+	"team.until":                 {Other: "%s まで"},
+	"team.whos_out.member":       {Other: "- @%s: %s から、%s に戻ります\n"},
+	"team.whos_out.none":         {Other: "今週は全員出勤です。\n"},
+	"team.whos_out.title":        {Other: "#### :palm_tree: 今週の不在者:\n"},
+
+	"thread.cancelled": {Other: "**_この予定はキャンセルされました:_**\n\n**~~[%s](%s)~~**\n"},
+	"thread.event":     {Other: "**_予定:_**\n"},
+}
+
+// japaneseHelp is the help of the command, the | are replaced by backquotes
+const japaneseHelp = `* |/calendar connect| - Google カレンダーを Mattermost アカウントと連携します
+
+---
+
+* |/calendar create| タイトル、開始日時、終了日時、参加者 (省略可) を指定して予定を作成します - Google Meet も自動で作成されます
+	* |Title| 予定のタイトルです。
+	* |Start DateTime| 予定の開始日時です。24 時間表記の YYYY-MM-DD@HH:MM 形式で指定します。
+		* 例: 2022-01-01@12:00
+	* |End DateTime| 予定の終了日時です。24 時間表記の YYYY-MM-DD@HH:MM 形式で指定します。
+		* 例: 2022-01-01@13:00
+	* |Attendees - 省略可| 招待する人のユーザー名またはメールアドレスの一覧です。角括弧 "[ ]" の中に、スペースで区切って入力します。
+		* 例: [@exampleuser-1, @exampleuser-2 example@gmail.com ...]
+	* |--force - 省略可| 参加予定の予定と重複する場合は作成されません。最後にこれを付けると、重複していても作成します。
+	**コマンドの例:** => | /calendar create 'my-meeting' 2022-01-01@12:00 2022-01-01@13:00 [exampleuser-1 exampleuser-2 example@gmail.com] |
+
+---
+
+* |/calendar edit [event]| - 今後の予定を編集します。一覧から予定を選び、ダイアログで変更します。
+	* |event| 自分が主催する今後の予定です。入力中に候補が表示されます。
+	* |Send updates| 変更をゲストにメールで知らせるかどうかを選べます。
+
+---
+
+* |/calendar summary [date]| - 指定した日の予定の概要を表示します。
+	* |date| 'today'、'tmr'、または YYYY-MM-DD 形式の日付です。
+	**コマンドの例:** => | /calendar summary today |  | /calendar summary tmr |  | /calendar summary 2022-01-01 |
+
+---
+
+* |/calendar agenda [range] [compact]| - 1週間または指定した期間の予定を日ごとに表示します。
+	* |range| 'this week'、'next week'、または YYYY-MM-DD..YYYY-MM-DD 形式の期間です。
+	* |compact| 表の代わりに予定を1行ずつ表示します。予定が多いときに便利です。
+	**コマンドの例:** => | /calendar agenda this week |  | /calendar agenda next week compact |  | /calendar agenda 2022-01-01..2022-01-14 |
+
+---
+
+* |/calendar conflicts [range]| - 重複している予定を一覧表示します。
+	* |range| agenda と同じく、'this week'、'next week'、または YYYY-MM-DD..YYYY-MM-DD 形式の期間です。
+	**コマンドの例:** => | /calendar conflicts |  | /calendar conflicts next week |
+
+---
+
+* |/calendar search <text> [--from date] [--to date] [--attendee @user]| - 予定を検索します。
+	* |text| 予定のタイトル、説明、場所、ゲストから検索します。
+	* |--from date - 省略可| この日付 (YYYY-MM-DD 形式) 以降の予定を検索します。省略すると今日からです。
+	* |--to date - 省略可| この日付 (YYYY-MM-DD 形式) までの予定を検索します。省略すると開始から 90 日後までです。
+	* |--attendee @user - 省略可| このユーザー名またはメールアドレスが招待されている予定のみを検索します。
+	**コマンドの例:** => | /calendar search planning --from 2022-01-01 --to 2022-01-31 --attendee @exampleuser-1 |
+
+---
+
+* |/calendar responses <event>| - 自分が主催する予定に、誰が参加、不参加、未定と返信したか、誰が未返信かを確認します。
+	* |event| 今後の予定です。候補の一覧から選びます。
+	* |Nudge pending| ボタンを押すと、未返信でこのプラグインを使っているゲストに、返信ボタン付きのリマインダーを送ります。
+
+---
+
+* |/calendar team [~channel|@group]| - 今、誰が空いているか、会議中か、不在かを確認します。
+	* |~channel|@group - 省略可| 確認するチャンネルまたはグループです。省略するとこのチャンネルです。Google カレンダーを連携したメンバーのみが表示され、会議名は自分が招待されている会議のみ表示されます。
+	* |/calendar team subscribe| 平日の毎朝、今週の不在者をこのチャンネルに投稿します。|/calendar team unsubscribe| で停止します。
+	**コマンドの例:** => | /calendar team |  | /calendar team ~town-square |  | /calendar team @developers |
+
+---
+
+* |/calendar admin| - プラグインを管理します (システム管理者のみ)。管理コマンドは |/calendar admin help| で確認できます。
+
+---
+
+* |/calendar settings| - ユーザー設定を確認、変更します。
+	* |次の項目を設定できます|
+		* |Allow notifications| チャンネルでの通知を許可します。
+		* |Time notify before event| 予定の何分前に通知するかです。正の整数 (分) で指定します (通知を許可している場合に有効です)。
+		* |All-day event reminder time| 終日の予定を通知する時刻です。24 時間表記の HH:MM 形式で指定します。
+		* |Notify when guests decline| 自分が主催する予定をゲストが辞退したときに通知します。
+		* |Out of office auto-reply| Google カレンダーに不在の予定があるとき、ダイレクトメッセージやメンションを送ってきた人に、数時間に一度まで自動で知らせます。
+		* |Out of office backup contact| 不在中に連絡してほしい人のユーザー名です。
+		* |Calendar updates| 1回の同期で見つかった変更を1つのメッセージにまとめるか、予定ごとのスレッドに1つずつ投稿するかを選びます。
+
+---
+
+* |/calendar next| - 今日の次の予定を表示します
+
+---
+
+* |/calendar disconnect| - Google カレンダーと Mattermost アカウントの連携を解除します
+
+---
+`
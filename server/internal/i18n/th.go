@@ -0,0 +1,438 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+// thai dates are in the Buddhist era, 543 years after the common era
+var thai = &locale{
+	messages:      thaiMessages,
+	weekdays:      [7]string{"วันอาทิตย์", "วันจันทร์", "วันอังคาร", "วันพุธ", "วันพฤหัสบดี", "วันศุกร์", "วันเสาร์"},
+	shortWeekdays: [7]string{"อา.", "จ.", "อ.", "พ.", "พฤ.", "ศ.", "ส."},
+	months: [12]string{"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน", "กรกฎาคม", "สิงหาคม", "กันยายน",
+		"ตุลาคม", "พฤศจิกายน", "ธันวาคม"},
+	shortMonths: [12]string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."},
+	am:          "ก่อนเที่ยง",
+	pm:          "หลังเที่ยง",
+	isOne:       isOneNever,
+	date: func(l *Localizer, t time.Time) string {
+		return fmt.Sprintf("%sที่ %d %s %d", l.Weekday(t.Weekday()), t.Day(), l.Month(t.Month()), t.Year()+543)
+	},
+	shortDate: func(l *Localizer, t time.Time) string {
+		return fmt.Sprintf("%s %d %s", l.ShortWeekday(t.Weekday()), t.Day(), l.ShortMonth(t.Month()))
+	},
+	clock12: func(l *Localizer, t time.Time) string {
+		return t.Format("3:04") + " " + l.meridiem(t)
+	},
+}
+
+var thaiMessages = map[string]Message{
+	// autocomplete of the command
+	"autocomplete.calendar":            {Other: "คำสั่งที่ใช้ได้: connect, create, edit, next, summary, agenda, conflicts, search, responses, team, settings, disconnect, help"},
+	"autocomplete.connect":             {Other: "เชื่อมต่อ Google Calendar กับบัญชี Mattermost ของคุณ"},
+	"autocomplete.create":              {Other: "สร้างกิจกรรมด้วยหัวข้อ วันเวลาเริ่มต้น วันเวลาสิ้นสุด และผู้เข้าร่วม"},
+	"autocomplete.create.title":        {Other: "หัวข้อของกิจกรรมที่จะสร้าง ต้องอยู่ในเครื่องหมายอัญประกาศเดี่ยว ตัวอย่าง: 'my-meeting'"},
+	"autocomplete.create.start":        {Other: "เวลาเริ่มต้นของกิจกรรมในรูปแบบ YYYY-MM-DD@HH:MM (2022-01-01@12:00)"},
+	"autocomplete.create.end":          {Other: "เวลาสิ้นสุดของกิจกรรมในรูปแบบ YYYY-MM-DD@HH:MM (2022-01-01@13:00)"},
+	"autocomplete.create.attendees":    {Other: "รายชื่อผู้ใช้หรืออีเมลของคนที่คุณต้องการเชิญ พิมพ์ในวงเล็บเหลี่ยม `[ ]` และคั่นแต่ละชื่อผู้ใช้หรืออีเมลด้วยช่องว่าง\n ตัวอย่าง: [example1 example2 example@gmail.com]"},
+	"autocomplete.create.force":        {Other: "สร้างกิจกรรมแม้ว่าจะทับซ้อนกับกิจกรรมที่คุณจะเข้าร่วม"},
+	"autocomplete.edit":                {Other: "แก้ไขกิจกรรมที่กำลังจะมาถึงของคุณ"},
+	"autocomplete.event":               {Other: "กิจกรรมที่กำลังจะมาถึงซึ่งคุณเป็นผู้จัด"},
+	"autocomplete.next":                {Other: "ดูกิจกรรมถัดไปของวันนี้"},
+	"autocomplete.summary":             {Other: "ดูสรุปกิจกรรมของวันที่ต้องการ"},
+	"autocomplete.summary.date":        {Other: "วันที่เป็นคำว่า [today] หรือ [tmr] หรือวันที่ในรูปแบบ YYYY-MM-DD (2022-01-01)"},
+	"autocomplete.agenda":              {Other: "ดูกำหนดการของสัปดาห์หรือช่วงเวลาที่ต้องการ"},
+	"autocomplete.agenda.range":        {Other: "ช่วงเวลาเป็นคำว่า [this week] หรือ [next week] หรือวันที่ในรูปแบบ YYYY-MM-DD..YYYY-MM-DD เพิ่ม [compact] เพื่อแสดงแบบย่อ"},
+	"autocomplete.conflicts":           {Other: "แสดงกิจกรรมของคุณที่เวลาทับซ้อนกัน"},
+	"autocomplete.conflicts.range":     {Other: "ช่วงเวลาเป็นคำว่า [this week] หรือ [next week] หรือวันที่ในรูปแบบ YYYY-MM-DD..YYYY-MM-DD"},
+	"autocomplete.search":              {Other: "ค้นหากิจกรรมของคุณ"},
+	"autocomplete.search.text":         {Other: "ข้อความที่จะค้นหาในหัวข้อ รายละเอียด สถานที่ และผู้เข้าร่วมของกิจกรรม"},
+	"autocomplete.search.from":         {Other: "ค้นหากิจกรรมตั้งแต่วันที่นี้ ในรูปแบบ YYYY-MM-DD"},
+	"autocomplete.search.to":           {Other: "ค้นหากิจกรรมจนถึงวันที่นี้ ในรูปแบบ YYYY-MM-DD"},
+	"autocomplete.search.attendee":     {Other: "ค้นหาเฉพาะกิจกรรมที่ชื่อผู้ใช้หรืออีเมลนี้ได้รับเชิญ"},
+	"autocomplete.responses":           {Other: "ดูว่าใครตอบรับกิจกรรมที่คุณจัดแล้วบ้าง และเตือนคนที่ยังไม่ตอบ"},
+	"autocomplete.team":                {Other: "ดูว่าใครว่าง ติดประชุม หรือไม่อยู่ที่ทำงาน"},
+	"autocomplete.team.target":         {Other: "ช่องหรือกลุ่มที่ต้องการดู ค่าเริ่มต้นคือช่องนี้ [subscribe] จะโพสต์รายชื่อคนที่ไม่อยู่ในสัปดาห์นี้ลงในช่องนี้ทุกวันทำงาน"},
+	"autocomplete.admin":               {Other: "จัดการปลั๊กอิน สำหรับผู้ดูแลระบบเท่านั้น"},
+	"autocomplete.admin.stats":         {Other: "ผู้ใช้ที่เชื่อมต่อ ช่องติดตามที่ใกล้หมดอายุ และข้อผิดพลาดในการซิงค์ล่าสุด"},
+	"autocomplete.admin.user":          {Other: "การเชื่อมต่อ การซิงค์ล่าสุด และสถานะโทเค็นของผู้ใช้"},
+	"autocomplete.admin.username":      {Other: "ชื่อผู้ใช้"},
+	"autocomplete.admin.resync":        {Other: "ลบกิจกรรมที่ซิงค์ไว้และซิงค์ใหม่ทั้งหมด"},
+	"autocomplete.admin.resync.target": {Other: "ชื่อผู้ใช้ หรือ all"},
+	"autocomplete.admin.disconnect":    {Other: "ยกเลิกการเชื่อมต่อ Google Calendar ของผู้ใช้"},
+	"autocomplete.admin.renew_watches": {Other: "ตั้งค่าช่องการแจ้งเตือนแบบพุชของผู้ใช้ทุกคนใหม่"},
+	"autocomplete.settings":            {Other: "การตั้งค่าผู้ใช้ คุณสามารถดูและเปลี่ยนการตั้งค่าได้"},
+	"autocomplete.disconnect":          {Other: "ยกเลิกการเชื่อมต่อ Google Calendar จากบัญชี Mattermost ของคุณ"},
+	"autocomplete.help":                {Other: "แสดงวิธีใช้งาน"},
+
+	"agenda.continued":             {Other: "%s (ต่อ)"},
+	"agenda.loading":               {Other: "กำลังดึงกำหนดการของคุณ..."},
+	"agenda.range.dates":           {Other: "%s - %s"},
+	"agenda.range.invalid":         {Other: "ช่วงเวลาไม่ถูกต้อง โปรดใช้ [this week], [next week] หรือ YYYY-MM-DD..YYYY-MM-DD"},
+	"agenda.range.invalid_end":     {Other: "รูปแบบวันที่สิ้นสุดไม่ถูกต้อง โปรดใช้ YYYY-MM-DD"},
+	"agenda.range.invalid_start":   {Other: "รูปแบบวันที่เริ่มต้นไม่ถูกต้อง โปรดใช้ YYYY-MM-DD"},
+	"agenda.range.next_week":       {Other: "สัปดาห์หน้า"},
+	"agenda.range.start_after_end": {Other: "วันที่เริ่มต้นต้องอยู่ก่อนวันที่สิ้นสุด"},
+	"agenda.range.this_week":       {Other: "สัปดาห์นี้"},
+	"agenda.range.too_long":        {Other: "ช่วงเวลาต้องไม่เกิน %d วัน"},
+	"agenda.response.links":        {Other: "[ไป](%s) / [ไม่ไป](%s) / [อาจจะ](%s)"},
+	"agenda.table_header":          {Other: "| เวลา | หัวข้อ | สถานที่ | เข้าร่วม? |"},
+	"agenda.time.all_day":          {Other: "ทั้งวัน"},
+	"agenda.time.all_day_of":       {Other: "ทั้งวัน, วันที่ %d จาก %d"},
+	"agenda.time.day_of":           {Other: "วันที่ %d จาก %d"},
+	"agenda.time.from":             {Other: "ตั้งแต่ %s, วันที่ %d จาก %d"},
+	"agenda.time.until":            {Other: "จนถึง %s, วันที่ %d จาก %d"},
+	"agenda.title":                 {Other: "#### กำหนดการ%s:\n"},
+
+	"card.delete":        {Other: "ลบ"},
+	"card.guests":        {Other: "ผู้เข้าร่วม"},
+	"card.guests.counts": {Other: "ไป %d, ไม่ไป %d, อาจจะ %d, รอตอบ %d"},
+	"card.join":          {Other: "เข้าร่วม"},
+	"card.join.link":     {Other: "[เข้าร่วมการประชุม](%s)"},
+	"card.organizer":     {Other: "ผู้จัด"},
+	"card.when":          {Other: "เมื่อ"},
+	"card.where":         {Other: "สถานที่"},
+
+	"changes.cancelled":         {Other: "**_กิจกรรมถูกยกเลิก:_**\n\n**~~[%s](%s)~~**\n**เมื่อ**: ~~%s~~\n"},
+	"changes.declined":          {Other: "**_ผู้เข้าร่วมปฏิเสธ:_**\n\n**[%s](%s)**\n"},
+	"changes.invited":           {Other: "**_คุณได้รับคำเชิญ:_**\n"},
+	"changes.section.added":     {Other: "คำเชิญใหม่"},
+	"changes.section.cancelled": {Other: "ยกเลิก"},
+	"changes.section.responses": {Other: "การตอบรับ"},
+	"changes.section.updated":   {Other: "อัปเดต"},
+	"changes.title":             {Other: "#### อัปเดตปฏิทิน (%d):\n"},
+
+	"command.description": {Other: "เชื่อมต่อกับ Google Calendar"},
+	"command.unknown":     {Other: "ไม่รู้จักคำสั่ง: `%v`"},
+
+	"conflicts.decline":       {Other: "  [ปฏิเสธ %s](%s) | [เสนอเวลาใหม่](%s)\n"},
+	"conflicts.double_booked": {Other: "**นัดซ้อน?**: [ปฏิเสธ](%s) | [เสนอเวลาใหม่](%s)\n"},
+	"conflicts.empty":         {Other: "ดูเหมือนว่าคุณไม่มีกิจกรรมที่เวลาทับซ้อนกัน"},
+	"conflicts.loading":       {Other: "กำลังค้นหากิจกรรมที่เวลาทับซ้อนกัน..."},
+	"conflicts.pair":          {Other: "- [%s](%s) %s\n  ทับซ้อนกับ [%s](%s) %s\n"},
+	"conflicts.title":         {Other: "#### กิจกรรมที่ทับซ้อนกัน%s:\n"},
+	"conflicts.warning":       {Other: "\n**:warning: ทับซ้อนกับ:**\n"},
+
+	"connect.link":    {Other: "[คลิกที่นี่เพื่อเชื่อมต่อ Google Calendar ของคุณ](%s)"},
+	"connect.synced":  {Other: "การแจ้งเตือนของ Google Calendar ซิงค์กับ Mattermost ของคุณแล้ว!"},
+	"connect.welcome": {Other: "#### ยินดีต้อนรับสู่ Mattermost Google Calendar Plugin!\nคุณเชื่อมต่อบัญชี Mattermost กับ Google Calendar เรียบร้อยแล้ว\nพิมพ์ **/calendar help** เพื่อดูวิธีใช้งานปลั๊กอินนี้"},
+
+	"date.today":    {Other: "วันนี้"},
+	"date.tomorrow": {Other: "พรุ่งนี้"},
+
+	"dialog.save": {Other: "บันทึก"},
+
+	"digest.title": {Other: "#### สรุปอัปเดตปฏิทิน (%d):\n"},
+
+	"disconnect.by_admin":      {Other: "ผู้ดูแลระบบยกเลิกการเชื่อมต่อ Google Calendar ของคุณ ใช้ `/calendar connect` เพื่อเชื่อมต่ออีกครั้ง"},
+	"disconnect.confirm":       {Other: "ยืนยัน"},
+	"disconnect.confirm.title": {Other: "ต้องการยกเลิกการเชื่อมต่อหรือไม่"},
+	"disconnect.failed":        {Other: "ยกเลิกการเชื่อมต่อปฏิทินไม่สำเร็จ"},
+	"disconnect.success":       {Other: "ยกเลิกการเชื่อมต่อปฏิทินแล้ว"},
+
+	"error.not_connected":           {Other: "โปรดเชื่อมต่อ Google Calendar ของคุณด้วยคำสั่ง => `/calendar connect`"},
+	"error.not_found":               {Other: "ไม่พบข้อมูล อาจถูกลบไปแล้ว"},
+	"error.not_organizer":           {Other: "คุณเปลี่ยนแปลงได้เฉพาะกิจกรรมที่คุณสร้างเท่านั้น"},
+	"error.not_organizer.delete":    {Other: "คุณลบได้เฉพาะกิจกรรมที่คุณสร้างเท่านั้น"},
+	"error.not_organizer.edit":      {Other: "คุณแก้ไขได้เฉพาะกิจกรรมที่คุณสร้างเท่านั้น"},
+	"error.not_organizer.nudge":     {Other: "คุณเตือนผู้เข้าร่วมได้เฉพาะกิจกรรมที่คุณสร้างเท่านั้น"},
+	"error.not_organizer.responses": {Other: "คุณดูการตอบรับได้เฉพาะกิจกรรมที่คุณสร้างเท่านั้น"},
+	"error.rate_limited":            {Other: "Google Calendar ได้รับคำขอมากเกินไป โปรดลองอีกครั้งในอีกสักครู่"},
+	"error.token_revoked":           {Other: "Google ไม่อนุญาตให้เข้าถึงปฏิทินของคุณแล้ว โปรดเชื่อมต่ออีกครั้งด้วย `/calendar connect`"},
+	"error.unknown":                 {Other: "เกิดข้อผิดพลาด: %s"},
+
+	"event.create.command":               {Other: "คำสั่งล่าสุดคือ `%s`"},
+	"event.create.conflict_check":        {Other: "ตรวจสอบกิจกรรมที่ชนกันในปฏิทินของคุณไม่สำเร็จ ข้อผิดพลาด: %v"},
+	"event.create.conflicts":             {Other: "ยังไม่ได้สร้างกิจกรรม"},
+	"event.create.failed":                {Other: "สร้างกิจกรรมไม่สำเร็จ ข้อผิดพลาด: %v"},
+	"event.create.force":                 {Other: "เพิ่ม `%s` ท้ายคำสั่งเพื่อสร้างกิจกรรมนี้ต่อไป"},
+	"event.create.invalid_end":           {Other: "รูปแบบวันเวลาสิ้นสุดไม่ถูกต้อง: %v"},
+	"event.create.invalid_start":         {Other: "รูปแบบวันเวลาเริ่มต้นไม่ถูกต้อง: %v"},
+	"event.create.missing_end":           {Other: "ไม่ได้ระบุวันเวลาสิ้นสุด"},
+	"event.create.missing_start":         {Other: "ไม่ได้ระบุวันเวลาเริ่มต้น"},
+	"event.create.missing_title":         {Other: "ไม่ได้ระบุชื่อ"},
+	"event.create.success":               {Other: "สร้างกิจกรรม _[%s](%s)_ ใน%s เรียบร้อยแล้ว"},
+	"event.create.title_quotes":          {Other: "ชื่อต้องอยู่ในเครื่องหมาย ' '"},
+	"event.delete":                       {Other: "[ลบกิจกรรม](%s)\n"},
+	"event.delete.failed":                {Other: "ลบกิจกรรมไม่สำเร็จ ข้อผิดพลาด: %v"},
+	"event.delete.success":               {Other: "ลบกิจกรรม _%s_ เรียบร้อยแล้ว"},
+	"event.description":                  {Other: "**รายละเอียด**: %s\n"},
+	"event.edit.failed":                  {Other: "แก้ไขกิจกรรมไม่สำเร็จ ข้อผิดพลาด: %v"},
+	"event.edit.field.attendees":         {Other: "ผู้เข้าร่วม"},
+	"event.edit.field.attendees.help":    {Other: "ชื่อผู้ใช้หรืออีเมลคั่นด้วยช่องว่าง ตัวอย่าง: @exampleuser-1 example@gmail.com"},
+	"event.edit.field.date.help":         {Other: "ใช้ YYYY-MM-DD สำหรับกิจกรรมทั้งวัน"},
+	"event.edit.field.description":       {Other: "รายละเอียด"},
+	"event.edit.field.end":               {Other: "วันเวลาสิ้นสุด"},
+	"event.edit.field.location":          {Other: "สถานที่"},
+	"event.edit.field.send_updates":      {Other: "ส่งการอัปเดต"},
+	"event.edit.field.start":             {Other: "วันเวลาเริ่มต้น"},
+	"event.edit.field.title":             {Other: "ชื่อ"},
+	"event.edit.field.title.placeholder": {Other: "ชื่อกิจกรรม"},
+	"event.edit.invalid_end":             {Other: "รูปแบบเวลาสิ้นสุดไม่ถูกต้อง [%v]"},
+	"event.edit.invalid_start":           {Other: "รูปแบบเวลาเริ่มต้นไม่ถูกต้อง [%v]"},
+	"event.edit.mixed_all_day":           {Other: "เวลาเริ่มต้นและสิ้นสุดต้องเป็นวันที่ทั้งคู่สำหรับกิจกรรมทั้งวัน หรือต้องมีเวลาทั้งคู่"},
+	"event.edit.send_updates.all":        {Other: "ส่งถึงแขกทุกคน"},
+	"event.edit.send_updates.external":   {Other: "ส่งถึงแขกนอกองค์กรของคุณ"},
+	"event.edit.send_updates.none":       {Other: "ไม่ส่ง"},
+	"event.edit.start_after_end":         {Other: "เวลาเริ่มต้นต้องอยู่ก่อนเวลาสิ้นสุด"},
+	"event.edit.title":                   {Other: "แก้ไขกิจกรรม"},
+	"event.edit.update_failed":           {Other: "อัปเดตกิจกรรมไม่สำเร็จ ข้อผิดพลาด: %v"},
+	"event.going":                        {Other: "**เข้าร่วม?**: %s"},
+	"event.going.links":                  {Other: "**เข้าร่วม?**: [ไป](%s) | [ไม่ไป](%s) | [อาจจะ](%s)"},
+	"event.guests":                       {Other: "**ผู้เข้าร่วม**: ไป %d, ไม่ไป %d, อาจจะ %d, รอตอบ %d\n"},
+	"event.guests.added":                 {Other: "**ผู้เข้าร่วมที่เพิ่มเข้ามา**: %s\n"},
+	"event.guests.external":              {Other: "ภายนอก"},
+	"event.guests.more":                  {Other: "และอีก %d คน"},
+	"event.guests.removed":               {Other: "**ผู้เข้าร่วมที่ถูกนำออก**: ~~%s~~\n"},
+	"event.guests.team":                  {Other: "ทีม"},
+	"event.meet":                         {Other: "**Meet**: %s\n"},
+	"event.meet.added":                   {Other: "**Meet**: %s (เพิ่ม)\n"},
+	"event.meet.removed":                 {Other: "**Meet**: ~~%s~~ (นำออก)\n"},
+	"event.missing":                      {Other: "ไม่ได้ระบุกิจกรรม กรุณาเลือกกิจกรรมที่กำลังจะมาถึงของคุณ"},
+	"event.not_found":                    {Other: "ไม่พบกิจกรรม `%s`"},
+	"event.organizer":                    {Other: "**ผู้จัด**: %s\n"},
+	"event.repeats":                      {Other: "**ทำซ้ำ**: %s\n"},
+	"event.repeats.none":                 {Other: "ไม่ซ้ำ"},
+	"event.respond.failed":               {Other: "ตอบรับกิจกรรมไม่สำเร็จ ข้อผิดพลาด: %v"},
+	"event.respond.success":              {Other: "อัปเดตการตอบรับกิจกรรม _%s_ เรียบร้อยแล้ว"},
+	"event.respond.update_failed":        {Other: "ผิดพลาด! อัปเดตการตอบรับกิจกรรม _%s_ ไม่สำเร็จ"},
+	"event.status":                       {Other: "**สถานะกิจกรรม**: %s\n"},
+	"event.status.cancelled":             {Other: "ยกเลิก"},
+	"event.status.confirmed":             {Other: "ยืนยันแล้ว"},
+	"event.status.tentative":             {Other: "ยังไม่แน่นอน"},
+	"event.updated.title":                {Other: "**_กิจกรรมถูกอัปเดต:_**\n"},
+	"event.when":                         {Other: "**เมื่อ**: %s\n"},
+	"event.when.all_day":                 {Other: "%s @ ทั้งวัน"},
+	"event.when.all_days":                {Other: "%s ถึง %s (ทั้งวัน)"},
+	"event.when.day_of":                  {Other: " (วันที่ %d จาก %d)"},
+	"event.when.time":                    {Other: "%s @ %s ถึง %s"},
+	"event.when.times":                   {Other: "%s @ %s ถึง %s @ %s"},
+	"event.when.zone":                    {Other: " (%s ถึง %s %s)"},
+	"event.where":                        {Other: "**สถานที่**: %s\n"},
+	"events.fetch_failed":                {Other: "ดึงข้อมูลกิจกรรมไม่สำเร็จ"},
+
+	"help":       {Other: thaiHelp},
+	"help.title": {Other: "###### Mattermost Google Calendar Plugin - วิธีใช้คำสั่ง\n"},
+
+	"next.empty":   {Other: "ดูเหมือนว่าวันนี้คุณไม่มีกิจกรรมแล้ว"},
+	"next.loading": {Other: "กำลังดึงกิจกรรมถัดไปของคุณ..."},
+	"next.title":   {Other: "#### กิจกรรมถัดไป:\n"},
+
+	"nudge.done":          {Other: "เตือน %s แล้ว"},
+	"nudge.none":          {Other: "ไม่มีใครที่เตือนได้"},
+	"nudge.not_connected": {Other: " ยังไม่ได้เชื่อมต่อ Google Calendar ใน Mattermost: %s"},
+	"nudge.organizer":     {Other: "ผู้จัด"},
+	"nudge.waiting":       {Other: "**_%s กำลังรอการตอบกลับจากคุณ:_**\n"},
+
+	"ooo.backup": {Other: "\nหากมีเรื่องด่วน โปรดติดต่อ @%s"},
+	"ooo.reply":  {Other: ":palm_tree: @%s ไม่อยู่ที่ทำงานจนถึง %s"},
+
+	"reminder.action.failed":      {Other: "ไม่สามารถอัปเดตการแจ้งเตือนของคุณได้ ข้อผิดพลาด: %v"},
+	"reminder.all_day":            {Other: "**_กิจกรรมทั้งวันวันนี้:_**"},
+	"reminder.join":               {Other: "เข้าร่วมเลย"},
+	"reminder.minutes":            {Other: "**_อีก %d นาทีจะถึงกิจกรรมนี้:_**"},
+	"reminder.mute":               {Other: "ปิดการแจ้งเตือนกิจกรรมนี้"},
+	"reminder.muted":              {Other: "คุณจะไม่ได้รับการแจ้งเตือนและการอัปเดตเกี่ยวกับ _%s_ อีก"},
+	"reminder.snooze":             {Other: "เลื่อน %d นาที"},
+	"reminder.snooze_until_start": {Other: "เลื่อนไปจนเริ่ม"},
+	"reminder.snoozed":            {Other: "**_การแจ้งเตือนที่เลื่อนไว้:_**"},
+	"reminder.snoozed.until":      {Other: "จะเตือนคุณอีกครั้งเวลา %s"},
+
+	"response.maybe":            {Other: "อาจจะ"},
+	"response.no":               {Other: "ไม่ไป"},
+	"response.status.accepted":  {Other: "%s ตอบรับแล้ว"},
+	"response.status.declined":  {Other: "%s ปฏิเสธแล้ว"},
+	"response.status.pending":   {Other: "%s ยังไม่ได้ตอบกลับ"},
+	"response.status.tentative": {Other: "%s อาจเข้าร่วม"},
+	"response.yes":              {Other: "ไป"},
+
+	"responses.accepted":  {Other: "ตอบรับ"},
+	"responses.declined":  {Other: "ปฏิเสธ"},
+	"responses.failed":    {Other: "โพสต์การตอบกลับไม่สำเร็จ"},
+	"responses.maybe":     {Other: "อาจจะ"},
+	"responses.no_guests": {Other: "กิจกรรมนี้ไม่มีแขก"},
+	"responses.nudge":     {Other: "เตือนผู้ที่ยังไม่ตอบ"},
+	"responses.pending":   {Other: "รอการตอบกลับ"},
+	"responses.title":     {Other: "#### การตอบกลับของ [%s](%s):\n"},
+
+	"search.empty":          {Other: "ไม่พบกิจกรรมที่ตรงกับการค้นหา"},
+	"search.from_after_to":  {Other: "วันที่ของ --from ต้องอยู่ก่อน --to"},
+	"search.invalid":        {Other: "การค้นหาไม่ถูกต้อง โปรดค้นหาอีกครั้ง"},
+	"search.invalid_from":   {Other: "รูปแบบวันที่ของ --from ไม่ถูกต้อง กรุณาใช้ YYYY-MM-DD"},
+	"search.invalid_to":     {Other: "รูปแบบวันที่ของ --to ไม่ถูกต้อง กรุณาใช้ YYYY-MM-DD"},
+	"search.loading":        {Other: "กำลังค้นหากิจกรรมของคุณ..."},
+	"search.missing_text":   {Other: "ไม่ได้ระบุข้อความที่จะค้นหา"},
+	"search.missing_value":  {Other: "ไม่ได้ระบุค่าของ %s"},
+	"search.more":           {Other: "เพิ่มเติม"},
+	"search.no_more":        {Other: "ไม่มีผลลัพธ์เพิ่มเติม"},
+	"search.title":          {Other: "#### ผลการค้นหา \"%s\" (%d-%d จาก %d):\n"},
+	"search.title.attendee": {Other: "#### กิจกรรมกับ %s (%d-%d จาก %d):\n"},
+
+	"settings.all_day_reminder":           {Other: "เวลาแจ้งเตือนกิจกรรมทั้งวัน"},
+	"settings.all_day_reminder.help":      {Other: "เวลาที่จะแจ้งเตือนกิจกรรมทั้งวัน ในรูปแบบ 24 ชั่วโมง HH:MM"},
+	"settings.allow_notify":               {Other: "อนุญาตการแจ้งเตือน"},
+	"settings.delivery.channel":           {Other: "~%s (%s)"},
+	"settings.delivery.dm":                {Other: "ข้อความส่วนตัวจากบอท"},
+	"settings.delivery.ephemeral":         {Other: "แสดงเฉพาะฉัน ไม่เก็บไว้"},
+	"settings.delivery.help":              {Other: "แสดงช่องส่วนตัวที่คุณสร้างด้วย บอทต้องเป็นสมาชิกของช่องนั้น"},
+	"settings.digest":                     {Other: "สรุปการอัปเดต"},
+	"settings.digest.help":                {Other: "รวมคำเชิญและการอัปเดตไว้ในสรุปแทนการส่งทันที"},
+	"settings.digest.hourly":              {Other: "ทุกชั่วโมง"},
+	"settings.digest.off":                 {Other: "ปิด"},
+	"settings.digest.twice_daily":         {Other: "วันละสองครั้ง เวลา 9:00 และ 17:00"},
+	"settings.digest_delivery":            {Other: "ส่งสรุปไปที่"},
+	"settings.invalid.backup_contact":     {Other: "ไม่พบผู้ใช้ %s สำหรับ `OOOBackupContact`"},
+	"settings.invalid.delivery_bot":       {Other: "เพิ่มบอทปฏิทินเข้าไปใน ~%s ก่อน เพื่อให้บอทโพสต์ในช่องนั้นได้"},
+	"settings.invalid.delivery_channel":   {Other: "ส่งการแจ้งเตือนได้เฉพาะช่องส่วนตัวที่คุณสร้างและยังเป็นสมาชิกอยู่เท่านั้น"},
+	"settings.invalid.quiet_hours":        {Other: "`%s` ต้องเป็นเวลาในรูปแบบ HH:MM โดยต้องตั้งช่วงเวลาเงียบทั้งสองค่าหรือเว้นว่างทั้งคู่"},
+	"settings.invalid.quiet_hours_same":   {Other: "`QuietHoursStart` และ `QuietHoursEnd` ต้องไม่ใช่เวลาเดียวกัน"},
+	"settings.invalid.reminder_time":      {Other: "`AllDayReminderTime` ต้องเป็นเวลาในรูปแบบ HH:MM"},
+	"settings.invalid.time_before":        {Other: "`TimeNotiBeforeEvent` ต้องเป็นตัวเลข"},
+	"settings.invalid.time_before_range":  {Other: "`TimeNotiBeforeEvent` ต้องอยู่ระหว่าง %d - %d"},
+	"settings.invite_delivery":            {Other: "ส่งคำเชิญไปที่"},
+	"settings.layout":                     {Other: "การอัปเดตปฏิทิน"},
+	"settings.layout.combined":            {Other: "รวมเป็นข้อความเดียว"},
+	"settings.layout.help":                {Other: "วิธีแจ้งเมื่อปฏิทินเปลี่ยนหลายรายการพร้อมกัน"},
+	"settings.layout.threads":             {Other: "แยกข้อความตามเธรดของแต่ละกิจกรรม"},
+	"settings.notify_decline":             {Other: "แจ้งเมื่อแขกปฏิเสธ"},
+	"settings.notify_decline.placeholder": {Other: "แจ้งฉันเมื่อแขกปฏิเสธกิจกรรมที่ฉันจัด"},
+	"settings.ooo_backup":                 {Other: "ผู้ติดต่อแทนเมื่อไม่อยู่"},
+	"settings.ooo_backup.help":            {Other: "ผู้ที่ควรติดต่อแทนระหว่างที่คุณไม่อยู่"},
+	"settings.ooo_reply":                  {Other: "ตอบกลับอัตโนมัติเมื่อไม่อยู่"},
+	"settings.ooo_reply.placeholder":      {Other: "แจ้งผู้ที่ส่งข้อความถึงฉันเมื่อฉันไม่อยู่"},
+	"settings.quiet_end":                  {Other: "สิ้นสุดช่วงเวลาเงียบ"},
+	"settings.quiet_end.help":             {Other: "เวลาสิ้นสุดช่วงเวลาเงียบ ในรูปแบบ 24 ชั่วโมง HH:MM เว้นว่างทั้งสองช่องเพื่อปิด"},
+	"settings.quiet_start":                {Other: "เริ่มช่วงเวลาเงียบ"},
+	"settings.quiet_start.help":           {Other: "ตั้งแต่เวลานี้ การอัปเดตปฏิทินจะรอจนช่วงเวลาเงียบสิ้นสุด ในรูปแบบ 24 ชั่วโมง HH:MM ยังคงส่งการแจ้งเตือนและการเปลี่ยนแปลงของกิจกรรมที่ใกล้จะเริ่ม"},
+	"settings.reminder_delivery":          {Other: "ส่งการแจ้งเตือนไปที่"},
+	"settings.time_before":                {Other: "แจ้งเตือนก่อนกิจกรรม (นาที)"},
+	"settings.time_before.help":           {Other: "เวลาก่อนกิจกรรมที่จะแจ้งเตือน เป็นจำนวนเต็มหน่วยนาทีระหว่าง 0 ถึง 40320"},
+	"settings.title":                      {Other: "การตั้งค่า"},
+	"settings.update_delivery":            {Other: "ส่งการอัปเดตกิจกรรมไปที่"},
+	"settings.updated":                    {Other: "อัปเดตการตั้งค่าเรียบร้อยแล้ว"},
+
+	"summary.empty":          {Other: "ดูเหมือนว่าคุณไม่มีกิจกรรม"},
+	"summary.invalid_date":   {Other: "รูปแบบวันที่ไม่ถูกต้อง โปรดใช้ YYYY-MM-DD"},
+	"summary.loading":        {Other: "กำลังดึงสรุปกิจกรรมของคุณ..."},
+	"summary.title.date":     {Other: "#### กำหนดการ%s:\n"},
+	"summary.title.today":    {Other: "#### กำหนดการวันนี้:\n"},
+	"summary.title.tomorrow": {Other: "#### กำหนดการพรุ่งนี้:\n"},
+
+	"sync.failed": {Other: "ซิงค์ Google Calendar ของคุณไม่สำเร็จ: %s\nหากคุณยกเลิกสิทธิ์การเข้าถึงหรือเปลี่ยนรหัสผ่าน โปรดเชื่อมต่ออีกครั้งด้วย `/calendar connect`"},
+
+	"team.back":                  {Other: "กลับมา %s"},
+	"team.busy":                  {Other: ":red_circle: อยู่ในประชุม"},
+	"team.channel_not_found":     {Other: "ไม่พบช่อง %s"},
+	"team.digest.add_bot_failed": {Other: "เพิ่มบอทเข้าช่องนี้ไม่สำเร็จ"},
+	"team.digest.already":        {Other: "ช่องนี้ได้รับโพสต์ \"ใครไม่อยู่\" รายวันอยู่แล้ว"},
+	"team.digest.not_subscribed": {Other: "ช่องนี้ไม่ได้รับโพสต์ \"ใครไม่อยู่\" รายวัน"},
+	"team.digest.subscribed":     {Other: "ช่องนี้จะได้รับรายชื่อคนที่ไม่อยู่ในสัปดาห์นี้ทุกวันทำการเวลา %s"},
+	"team.digest.unsubscribed":   {Other: "ช่องนี้จะไม่ได้รับโพสต์ \"ใครไม่อยู่\" รายวันอีกต่อไป"},
+	"team.free":                  {Other: ":large_green_circle: ว่าง"},
+	"team.free_rest":             {Other: "ว่างตลอดวันที่เหลือ"},
+	"team.group_not_found":       {Other: "ไม่พบกลุ่ม %s"},
+	"team.header":                {Other: "| สมาชิก | สถานะ | |\n|:-------|:-------|:--|\n"},
+	"team.next":                  {Other: "ประชุมถัดไปเวลา %s"},
+	"team.nobody":                {Other: "ยังไม่มีใครใน %s เชื่อมต่อ Google Calendar"},
+	"team.out":                   {Other: ":palm_tree: ไม่อยู่"},
+	"team.this_channel":          {Other: "ช่องนี้"},
+	"team.title":                 {Other: "#### สถานะของ %s (%s):\n"},
+	"team.until":                 {Other: "จนถึง %s"},
+	"team.whos_out.member":       {Other: "- @%s: ตั้งแต่ %s กลับมา %s\n"},
+	"team.whos_out.none":         {Other: "สัปดาห์นี้ทุกคนอยู่\n"},
+	"team.whos_out.title":        {Other: "#### :palm_tree: ใครไม่อยู่สัปดาห์นี้:\n"},
+
+	"thread.cancelled": {Other: "**_กิจกรรมนี้ถูกยกเลิกแล้ว:_**\n\n**~~[%s](%s)~~**\n"},
+	"thread.event":     {Other: "**_กิจกรรม:_**\n"},
+}
+
+// thaiHelp is the help of the command, the | are replaced by backquotes
+const thaiHelp = `* |/calendar connect| - เชื่อมต่อ Google Calendar กับบัญชี Mattermost ของคุณ
+
+---
+
+* |/calendar create| สร้างกิจกรรมด้วยหัวข้อ วันเวลาเริ่มต้น วันเวลาสิ้นสุด และผู้เข้าร่วม (ไม่บังคับ) - คำสั่งนี้จะสร้าง Google Meet ให้อัตโนมัติ
+	* |Title| หัวข้อของกิจกรรม
+	* |Start DateTime| เวลาเริ่มต้นของกิจกรรม ในรูปแบบ YYYY-MM-DD@HH:MM แบบ 24 ชั่วโมง
+		* ตัวอย่าง: 2022-01-01@12:00
+	* |End DateTime| เวลาสิ้นสุดของกิจกรรม ในรูปแบบ YYYY-MM-DD@HH:MM แบบ 24 ชั่วโมง
+		* ตัวอย่าง: 2022-01-01@13:00
+	* |Attendees - ไม่บังคับ| รายชื่อผู้ใช้หรืออีเมลของคนที่คุณต้องการเชิญ พิมพ์ในวงเล็บเหลี่ยม "[ ]" และคั่นแต่ละชื่อด้วยช่องว่าง
+		* ตัวอย่าง: [@exampleuser-1, @exampleuser-2 example@gmail.com ...]
+	* |--force - ไม่บังคับ| กิจกรรมจะไม่ถูกสร้างหากทับซ้อนกับกิจกรรมที่คุณจะเข้าร่วม เพิ่มคำนี้ไว้ท้ายคำสั่งเพื่อสร้างอยู่ดี
+	**ตัวอย่างคำสั่ง:** => | /calendar create 'my-meeting' 2022-01-01@12:00 2022-01-01@13:00 [exampleuser-1 exampleuser-2 example@gmail.com] |
+
+---
+
+* |/calendar edit [event]| - แก้ไขกิจกรรมที่กำลังจะมาถึง เลือกกิจกรรมจากรายการแล้วแก้ไขในหน้าต่าง
+	* |event| กิจกรรมที่กำลังจะมาถึงซึ่งคุณเป็นผู้จัด รายการจะแสดงขณะพิมพ์
+	* |Send updates| เลือกว่าจะส่งอีเมลแจ้งการเปลี่ยนแปลงให้ผู้เข้าร่วมหรือไม่
+
+---
+
+* |/calendar summary [date]| - ดูสรุปกิจกรรมของวันที่ต้องการ
+	* |date| เป็นคำว่า 'today' หรือ 'tmr' หรือวันที่ในรูปแบบ YYYY-MM-DD
+	**ตัวอย่างคำสั่ง:** => | /calendar summary today |  | /calendar summary tmr |  | /calendar summary 2022-01-01 |
+
+---
+
+* |/calendar agenda [range] [compact]| - ดูกำหนดการของสัปดาห์หรือช่วงเวลาที่ต้องการ แยกตามวัน
+	* |range| เป็นคำว่า 'this week' หรือ 'next week' หรือวันที่ในรูปแบบ YYYY-MM-DD..YYYY-MM-DD
+	* |compact| แสดงกิจกรรมละหนึ่งบรรทัดแทนตาราง เหมาะกับปฏิทินที่มีกิจกรรมมาก
+	**ตัวอย่างคำสั่ง:** => | /calendar agenda this week |  | /calendar agenda next week compact |  | /calendar agenda 2022-01-01..2022-01-14 |
+
+---
+
+* |/calendar conflicts [range]| - แสดงกิจกรรมของคุณที่เวลาทับซ้อนกัน
+	* |range| เหมือนกับ agenda เป็นคำว่า 'this week' หรือ 'next week' หรือวันที่ในรูปแบบ YYYY-MM-DD..YYYY-MM-DD
+	**ตัวอย่างคำสั่ง:** => | /calendar conflicts |  | /calendar conflicts next week |
+
+---
+
+* |/calendar search <text> [--from date] [--to date] [--attendee @user]| - ค้นหากิจกรรมของคุณ
+	* |text| ค้นหาในหัวข้อ รายละเอียด สถานที่ และผู้เข้าร่วมของกิจกรรม
+	* |--from date - ไม่บังคับ| ค้นหากิจกรรมตั้งแต่วันที่นี้ ในรูปแบบ YYYY-MM-DD ค่าเริ่มต้นคือวันนี้
+	* |--to date - ไม่บังคับ| ค้นหากิจกรรมจนถึงวันที่นี้ ในรูปแบบ YYYY-MM-DD ค่าเริ่มต้นคือ 90 วันหลังวันเริ่มต้น
+	* |--attendee @user - ไม่บังคับ| ค้นหาเฉพาะกิจกรรมที่ชื่อผู้ใช้หรืออีเมลนี้ได้รับเชิญ
+	**ตัวอย่างคำสั่ง:** => | /calendar search planning --from 2022-01-01 --to 2022-01-31 --attendee @exampleuser-1 |
+
+---
+
+* |/calendar responses <event>| - ดูว่าใครตอบรับ ปฏิเสธ ตอบว่าอาจจะ หรือยังไม่ตอบกิจกรรมที่คุณเป็นผู้จัด
+	* |event| กิจกรรมที่กำลังจะมาถึงของคุณ เลือกจากรายการที่แสดง
+	* |Nudge pending| ปุ่มนี้จะส่งการเตือนพร้อมปุ่มตอบรับให้ผู้เข้าร่วมที่ยังไม่ตอบและใช้ปลั๊กอินนี้
+
+---
+
+* |/calendar team [~channel|@group]| - ดูว่าตอนนี้ใครว่าง ติดประชุม หรือไม่อยู่ที่ทำงาน
+	* |~channel|@group - ไม่บังคับ| ช่องหรือกลุ่มที่ต้องการดู ค่าเริ่มต้นคือช่องนี้ จะแสดงเฉพาะสมาชิกที่เชื่อมต่อ Google Calendar และแสดงชื่อเฉพาะการประชุมที่คุณได้รับเชิญ
+	* |/calendar team subscribe| โพสต์รายชื่อคนที่ไม่อยู่ในสัปดาห์นี้ลงในช่องนี้ทุกเช้าวันทำงาน ใช้ |/calendar team unsubscribe| เพื่อหยุด
+	**ตัวอย่างคำสั่ง:** => | /calendar team |  | /calendar team ~town-square |  | /calendar team @developers |
+
+---
+
+* |/calendar admin| - จัดการปลั๊กอิน สำหรับผู้ดูแลระบบเท่านั้น ใช้ |/calendar admin help| เพื่อดูคำสั่งสำหรับผู้ดูแล
+
+---
+
+* |/calendar settings| - การตั้งค่าผู้ใช้ คุณสามารถดูและเปลี่ยนการตั้งค่าได้
+	* |คุณสามารถตั้งค่าเหล่านี้ได้|
+		* |Allow notifications| อนุญาตให้ปฏิทินแจ้งเตือนคุณในช่อง
+		* |Time notify before event| เวลาก่อนเริ่มกิจกรรมที่จะแจ้งเตือน ต้องเป็นจำนวนเต็มบวกหน่วยเป็นนาที (มีผลเมื่อคุณอนุญาตการแจ้งเตือน)
+		* |All-day event reminder time| เวลาของวันที่จะแจ้งเตือนกิจกรรมทั้งวัน ในรูปแบบ HH:MM แบบ 24 ชั่วโมง
+		* |Notify when guests decline| แจ้งคุณเมื่อผู้เข้าร่วมปฏิเสธกิจกรรมที่คุณเป็นผู้จัด
+		* |Out of office auto-reply| เมื่อคุณมีกิจกรรมไม่อยู่ที่ทำงานใน Google Calendar จะแจ้งคนที่ส่งข้อความถึงคุณหรือกล่าวถึงคุณ อย่างมากหนึ่งครั้งในทุกไม่กี่ชั่วโมง
+		* |Out of office backup contact| ชื่อผู้ใช้ที่คนอื่นควรติดต่อระหว่างที่คุณไม่อยู่
+		* |Calendar updates| รับการเปลี่ยนแปลงที่พบในการซิงค์หนึ่งครั้งเป็นข้อความรวมข้อความเดียว หรือแยกเป็นข้อความในเธรดของแต่ละกิจกรรม
+
+---
+
+* |/calendar next| - ดูกิจกรรมถัดไปของวันนี้
+
+---
+
+* |/calendar disconnect| - ยกเลิกการเชื่อมต่อ Google Calendar จากบัญชี Mattermost ของคุณ
+
+---
+`
@@ -0,0 +1,270 @@
+// Package metrics is a small registry of counters, gauges and histograms written in the
+// Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds of histograms, in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry holds the metrics to expose
+type Registry struct {
+	mutex      sync.Mutex
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// WriteText writes every metric in the Prometheus text format
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mutex.Lock()
+	collectors := append([]collector{}, r.collectors...)
+	r.mutex.Unlock()
+
+	buf := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(buf)
+	}
+	return buf.Flush()
+}
+
+// vec keeps one value per combination of label values
+type vec struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+	mutex  sync.Mutex
+}
+
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", v.name, len(v.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (v *vec) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, escapeHelp(v.help), v.name, v.kind)
+}
+
+// formatLabels renders {a="1",b="2"}, extra is appended as is, for the le label of histograms
+func (v *vec) formatLabels(key string, extra string) string {
+	var pairs []string
+	if len(v.labels) > 0 {
+		values := strings.Split(key, "\xff")
+		for i, label := range v.labels {
+			pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, escapeLabel(values[i])))
+		}
+	}
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// CounterVec is a counter partitioned by labels
+type CounterVec struct {
+	vec
+	values map[string]float64
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		vec:    vec{name: name, help: help, kind: "counter", labels: labels},
+		values: make(map[string]float64),
+	}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the counter of the label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a positive value to the counter of the label values
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+	key := c.key(labelValues)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values[key] += value
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.writeHeader(w)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.formatLabels(key, ""), formatFloat(c.values[key]))
+	}
+}
+
+// GaugeVec is a gauge partitioned by labels
+type GaugeVec struct {
+	vec
+	values map[string]float64
+}
+
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{
+		vec:    vec{name: name, help: help, kind: "gauge", labels: labels},
+		values: make(map[string]float64),
+	}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	key := g.key(labelValues)
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.values[key] = value
+}
+
+func (g *GaugeVec) Add(value float64, labelValues ...string) {
+	key := g.key(labelValues)
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.values[key] += value
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.writeHeader(w)
+	for _, key := range sortedKeys(g.values) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.formatLabels(key, ""), formatFloat(g.values[key]))
+	}
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec is a histogram partitioned by labels
+type HistogramVec struct {
+	vec
+	buckets []float64
+	values  map[string]*histogram
+}
+
+// NewHistogramVec creates a histogram, DefaultBuckets are used when buckets is nil
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	h := &HistogramVec{
+		vec:     vec{name: name, help: help, kind: "histogram", labels: labels},
+		buckets: buckets,
+		values:  make(map[string]*histogram),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds a value to the histogram of the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			hist.counts[i]++
+		}
+	}
+	hist.sum += value
+	hist.count++
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.writeHeader(w)
+
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		hist := h.values[key]
+		for i, bound := range h.buckets {
+			le := fmt.Sprintf("le=\"%s\"", formatFloat(bound))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.formatLabels(key, le), hist.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.formatLabels(key, "le=\"+Inf\""), hist.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.formatLabels(key, ""), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.formatLabels(key, ""), hist.count)
+	}
+}
+
+func sortedKeys(values map[string]float64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	help = strings.ReplaceAll(help, "\\", "\\\\")
+	return strings.ReplaceAll(help, "\n", "\\n")
+}
+
+func escapeLabel(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	return strings.ReplaceAll(value, "\n", "\\n")
+}
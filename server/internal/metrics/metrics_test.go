@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func writeText(t *testing.T, r *Registry) string {
+	t.Helper()
+	var sb strings.Builder
+	if err := r.WriteText(&sb); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	return sb.String()
+}
+
+func TestCounterVec(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("requests_total", "Requests by method.", "method", "code")
+	c.Inc("GET", "200")
+	c.Inc("GET", "200")
+	c.Add(2.5, "POST", "500")
+	c.Add(-1, "POST", "500")
+
+	want := `# HELP requests_total Requests by method.
+# TYPE requests_total counter
+requests_total{method="GET",code="200"} 2
+requests_total{method="POST",code="500"} 2.5
+`
+	if got := writeText(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCounterVecWithoutLabels(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("failures_total", "Failures.")
+	want := "# HELP failures_total Failures.\n# TYPE failures_total counter\n"
+	if got := writeText(t, r); got != want {
+		t.Errorf("empty counter got:\n%s\nwant:\n%s", got, want)
+	}
+
+	c.Inc()
+	want += "failures_total 1\n"
+	if got := writeText(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGaugeVec(t *testing.T) {
+	r := NewRegistry()
+	g := r.NewGaugeVec("queue_depth", "Depth of the queues.", "queue")
+	g.Set(3, "reminders")
+	g.Add(-1, "reminders")
+	g.Add(4, "digests")
+
+	want := `# HELP queue_depth Depth of the queues.
+# TYPE queue_depth gauge
+queue_depth{queue="digests"} 4
+queue_depth{queue="reminders"} 2
+`
+	if got := writeText(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("duration_seconds", "Durations.", []float64{1, 0.5}, "type")
+	h.Observe(0.2, "full")
+	h.Observe(0.5, "full")
+	h.Observe(3, "full")
+
+	want := `# HELP duration_seconds Durations.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{type="full",le="0.5"} 2
+duration_seconds_bucket{type="full",le="1"} 2
+duration_seconds_bucket{type="full",le="+Inf"} 3
+duration_seconds_sum{type="full"} 3.7
+duration_seconds_count{type="full"} 3
+`
+	if got := writeText(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHistogramDefaultBuckets(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogramVec("lag_seconds", "Lag.", nil)
+	h.Observe(100)
+
+	got := writeText(t, r)
+	if n := strings.Count(got, "lag_seconds_bucket{"); n != len(DefaultBuckets)+1 {
+		t.Errorf("got %d buckets, want %d:\n%s", n, len(DefaultBuckets)+1, got)
+	}
+	for _, line := range []string{
+		`lag_seconds_bucket{le="30"} 0`,
+		`lag_seconds_bucket{le="+Inf"} 1`,
+		`lag_seconds_sum 100`,
+		`lag_seconds_count 1`,
+	} {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("missing %q in:\n%s", line, got)
+		}
+	}
+}
+
+func TestEscaping(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("escaped_total", "Help with \\ and\nnew line.", "value")
+	c.Inc("a \"quoted\" \\ value\nwith new line")
+
+	want := `# HELP escaped_total Help with \\ and\nnew line.
+# TYPE escaped_total counter
+escaped_total{value="a \"quoted\" \\ value\nwith new line"} 1
+`
+	if got := writeText(t, r); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0"},
+		{1, "1"},
+		{0.005, "0.005"},
+		{1e21, "1e+21"},
+		{math.Inf(1), "+Inf"},
+		{math.Inf(-1), "-Inf"},
+		{math.NaN(), "NaN"},
+	}
+	for _, tt := range tests {
+		if got := formatFloat(tt.value); got != tt.want {
+			t.Errorf("formatFloat(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRegistryOrder(t *testing.T) {
+	r := NewRegistry()
+	r.NewGaugeVec("b_gauge", "B.").Set(1)
+	r.NewCounterVec("a_total", "A.").Inc()
+
+	got := writeText(t, r)
+	if strings.Index(got, "b_gauge") > strings.Index(got, "a_total") {
+		t.Errorf("metrics not written in registration order:\n%s", got)
+	}
+}
+
+func TestWrongLabelCountPanics(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounterVec("labeled_total", "Labeled.", "a", "b")
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc with a missing label value didn't panic")
+		}
+	}()
+	c.Inc("only one")
+}
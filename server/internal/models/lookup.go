@@ -1,6 +1,9 @@
 package model
 
 import (
+	"encoding/json"
+	"time"
+
 	dbmodel "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 )
 
@@ -19,3 +22,34 @@ type LookupsRequest struct {
 func (l LookupsRequest) IsValid() bool {
 	return l.UserID != "" && l.Key != ""
 }
+
+// SyncStatus is the outcome of the last calendar syncs of a user
+type SyncStatus struct {
+	LastSync    time.Time `json:"lastSync"`
+	LastError   string    `json:"lastError"`
+	LastErrorAt time.Time `json:"lastErrorAt"`
+	// FailureNotified is set once the user was told about the failing syncs
+	FailureNotified bool `json:"failureNotified"`
+}
+
+// SnoozedReminder is a reminder the user asked to get again later
+type SnoozedReminder struct {
+	EventID  string    `json:"eventId"`
+	RemindAt time.Time `json:"remindAt"`
+}
+
+// MutedEvent is an event, or a whole recurring series, the user doesn't want to hear about anymore
+type MutedEvent struct {
+	EventID string `json:"eventId"`
+	Title   string `json:"title"`
+}
+
+// QueuedNotification is a calendar change kept for the next digest of the user, or for the end of
+// their quiet hours. It is printed when the digest is posted, from the stored event and the event
+// as it was before the change
+type QueuedNotification struct {
+	EventID  string          `json:"eventId"`
+	Section  string          `json:"section"`            // added, updated, cancelled or responses
+	OldEvent json.RawMessage `json:"oldEvent,omitempty"` // calendar.Event, empty for new events
+	QueuedAt time.Time       `json:"queuedAt"`
+}
@@ -27,7 +27,19 @@ type UpdateUser struct {
 }
 
 type UserSettings struct {
-	TimeNotiBeforeEvent int `json:"timeNotiBeforeEvent"`
+	TimeNotiBeforeEvent    int    `json:"timeNotiBeforeEvent"`
+	AllDayReminderTime     string `json:"allDayReminderTime"`     // HH:MM
+	SyncNotificationLayout string `json:"syncNotificationLayout"` // combined or threads
+	NotifyOnDecline        bool   `json:"notifyOnDecline"`
+	OOOAutoReply           bool   `json:"oooAutoReply"`
+	OOOBackupContact       string `json:"oooBackupContact"` // username without @
+	QuietHoursStart        string `json:"quietHoursStart"`  // HH:MM, empty when off
+	QuietHoursEnd          string `json:"quietHoursEnd"`    // HH:MM, empty when off
+	DigestMode             string `json:"digestMode"`       // off, hourly or twice_daily
+	InviteDelivery         string `json:"inviteDelivery"`   // dm, ephemeral or a channel ID
+	UpdateDelivery         string `json:"updateDelivery"`   // dm, ephemeral or a channel ID
+	ReminderDelivery       string `json:"reminderDelivery"` // dm, ephemeral or a channel ID
+	DigestDelivery         string `json:"digestDelivery"`   // dm, ephemeral or a channel ID
 }
 
 type ListUsersOption struct {
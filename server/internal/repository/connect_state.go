@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 	"gorm.io/gorm"
 )
@@ -26,8 +28,7 @@ func NewConnectStateRepository(db *gorm.DB) ConnectStateRepository {
 func (c *connectStateRepository) Insert(state dbmodel.ConnectStates) error {
 	// insert state
 	if err := c.db.Create(&state).Error; err != nil {
-		fmt.Printf("error create connect state: %v", err)
-		return err
+		return fmt.Errorf("creating connect state: %w", err)
 	}
 	return nil
 }
@@ -35,14 +36,17 @@ func (c *connectStateRepository) Insert(state dbmodel.ConnectStates) error {
 func (c *connectStateRepository) Get(userID string) (string, error) {
 	var stateResponse dbmodel.ConnectStates
 	if err := c.db.Model(&dbmodel.ConnectStates{}).Where("user_id = ?", userID).First(&stateResponse).Error; err != nil {
-		return "", err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fmt.Errorf("connect state: %w", apperr.ErrNotFound)
+		}
+		return "", fmt.Errorf("getting connect state: %w", err)
 	}
 	return stateResponse.State, nil
 }
 
 func (c *connectStateRepository) Delete(userID string) error {
 	if err := c.db.Where("user_id = ?", userID).Delete(&dbmodel.ConnectStates{}).Error; err != nil {
-		return err
+		return fmt.Errorf("deleting connect state: %w", err)
 	}
 	return nil
 }
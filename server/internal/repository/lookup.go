@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 	"gorm.io/gorm"
@@ -13,6 +15,7 @@ type LookupRepository interface {
 	Update(dbmodel.Lookups) error
 	Get(models.LookupsRequest) (*dbmodel.Lookups, error)
 	Delete(models.LookupsRequest) error
+	DeleteKeys(string, []string) error
 	DeleteAllForUser(string) error
 	TransactionDelete(*gorm.DB, string) error
 }
@@ -31,8 +34,7 @@ func (l *lookupRepository) Insert(lookup dbmodel.Lookups) error {
 	// insert lookup
 	res := l.db.Create(&lookup)
 	if res.Error != nil {
-		fmt.Printf("error inserting lookup: %v\n", res.Error)
-		return res.Error
+		return fmt.Errorf("inserting lookup %s: %w", lookup.Key, res.Error)
 	}
 	return nil
 }
@@ -40,7 +42,7 @@ func (l *lookupRepository) Insert(lookup dbmodel.Lookups) error {
 func (l *lookupRepository) Update(lookup dbmodel.Lookups) error {
 	// update lookup value where user_id, type, key
 	if err := l.db.Model(&lookup).Where("user_id = ? AND key = ?", lookup.UserID, lookup.Key).Updates(&lookup).Error; err != nil {
-		return err
+		return fmt.Errorf("updating lookup %s: %w", lookup.Key, err)
 	}
 	return nil
 }
@@ -48,8 +50,10 @@ func (l *lookupRepository) Update(lookup dbmodel.Lookups) error {
 func (l *lookupRepository) Get(lookupRequest models.LookupsRequest) (*dbmodel.Lookups, error) {
 	var lookupResponse dbmodel.Lookups
 	if err := l.db.Model(&models.Lookups{}).Where("user_id = ? AND key = ?", lookupRequest.UserID, lookupRequest.Key).First(&lookupResponse).Error; err != nil {
-		fmt.Printf("error getting lookup repo: %v\n", err)
-		return nil, err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("lookup %s: %w", lookupRequest.Key, apperr.ErrNotFound)
+		}
+		return nil, fmt.Errorf("getting lookup %s: %w", lookupRequest.Key, err)
 	}
 	return &lookupResponse, nil
 }
@@ -58,8 +62,16 @@ func (l *lookupRepository) Delete(lookup models.LookupsRequest) error {
 	// delete lookup where user_id, type, key
 	res := l.db.Where("user_id = ? AND key = ?", lookup.UserID, lookup.Key).Delete(&models.Lookups{})
 	if res.Error != nil {
-		fmt.Printf("error deleting lookup: %v\n", res.Error)
-		return res.Error
+		return fmt.Errorf("deleting lookup %s: %w", lookup.Key, res.Error)
+	}
+	return nil
+}
+
+func (l *lookupRepository) DeleteKeys(userID string, keys []string) error {
+	// delete lookups where user_id, key in keys
+	res := l.db.Where("user_id = ? AND key IN ?", userID, keys).Delete(&models.Lookups{})
+	if res.Error != nil {
+		return fmt.Errorf("deleting %d lookups: %w", len(keys), res.Error)
 	}
 	return nil
 }
@@ -68,8 +80,7 @@ func (l *lookupRepository) DeleteAllForUser(userID string) error {
 	// delete lookup where user_id
 	res := l.db.Where("user_id = ?", userID).Delete(&models.Lookups{})
 	if res.Error != nil {
-		fmt.Printf("error deleting lookup: %v\n", res.Error)
-		return res.Error
+		return fmt.Errorf("deleting lookups of user: %w", res.Error)
 	}
 	return nil
 }
@@ -78,8 +89,7 @@ func (l *lookupRepository) TransactionDelete(tx *gorm.DB, userID string) error {
 	// delete lookup where user_id
 	res := tx.Where("user_id = ?", userID).Delete(&models.Lookups{})
 	if res.Error != nil {
-		fmt.Printf("error deleting lookup: %v\n", res.Error)
-		return res.Error
+		return fmt.Errorf("deleting lookups of user: %w", res.Error)
 	}
 	return nil
 }
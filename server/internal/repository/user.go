@@ -3,8 +3,8 @@ package repository
 import (
 	"errors"
 	"fmt"
-	"log"
 
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/pagination"
@@ -32,14 +32,12 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 
 func (u *userRepository) Create(user dbmodel.Users) (dbmodel.Users, error) {
 	if err := u.db.Create(&user).Error; err != nil {
-		return dbmodel.Users{}, err
+		return dbmodel.Users{}, fmt.Errorf("creating user: %w", err)
 	}
 	return user, nil
 }
 
 func (u *userRepository) Update(user *dbmodel.Users, updateData *dbmodel.Users) (*dbmodel.Users, error) {
-	fmt.Printf("user: %+v\n", user)
-	fmt.Printf("updateData: %+v\n", updateData)
 	if updateData == nil {
 		return nil, errors.New("update data is nil")
 	}
@@ -57,8 +55,7 @@ func (u *userRepository) Update(user *dbmodel.Users, updateData *dbmodel.Users)
 		user.AllowNotify = updateData.AllowNotify
 	}
 	if err := u.db.Save(user).Error; err != nil {
-		log.Printf("error when save user: %v\n", err)
-		return nil, err
+		return nil, fmt.Errorf("saving user: %w", err)
 	}
 	return user, nil
 }
@@ -67,11 +64,11 @@ func (u *userRepository) FindByUserID(id string) (*dbmodel.Users, error) {
 	var user dbmodel.Users
 	result := u.db.First(&user, "id = ?", id)
 	if result.Error != nil {
-		if result.Error == gorm.ErrRecordNotFound {
-			return nil, nil
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, apperr.ErrNotFound
 		}
 		// other error
-		return nil, result.Error
+		return nil, fmt.Errorf("finding user: %w", result.Error)
 	}
 
 	return &user, nil
@@ -90,10 +87,7 @@ func (u *userRepository) List(opts models.ListUsersOption) (*pagination.Paginati
 	}
 	// execute
 	if err := query.Find(&users).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
-		}
-		return nil, err
+		return nil, fmt.Errorf("listing users: %w", err)
 	}
 
 	paging.Rows = users
@@ -105,33 +99,31 @@ func (u *userRepository) Delete(userID string) (err error) {
 	tx := u.db.Begin()
 	defer func() {
 		if err != nil {
-			if err := tx.Rollback().Error; err != nil {
-				log.Printf("error when rollback transaction: %v\n", err)
+			if rollbackErr := tx.Rollback().Error; rollbackErr != nil {
+				err = fmt.Errorf("%w (rolling back: %v)", err, rollbackErr)
 			}
 			return
 		}
 
 		// commit
-		if err := tx.Commit().Error; err != nil {
-			log.Printf("error when commit transaction: %v\n", err)
+		if commitErr := tx.Commit().Error; commitErr != nil {
+			err = fmt.Errorf("committing user deletion: %w", commitErr)
 		}
 	}()
 
 	// delete lookups
 	if err = tx.Where("user_id = ?", userID).Delete(&dbmodel.Lookups{}).Error; err != nil {
-		return err
+		return fmt.Errorf("deleting lookups of user: %w", err)
 	}
 
 	// delete user
 	if err = tx.Where("id = ?", userID).Delete(&dbmodel.Users{}).Error; err != nil {
-		return err
+		return fmt.Errorf("deleting user: %w", err)
 	}
 	return nil
 }
 
 func (u *userRepository) TransactionUpdate(tx *gorm.DB, user *dbmodel.Users, updateData *dbmodel.Users) (*dbmodel.Users, error) {
-	fmt.Printf("user: %+v\n", user)
-	fmt.Printf("updateData: %+v\n", updateData)
 	if updateData == nil {
 		return nil, errors.New("update data is nil")
 	}
@@ -149,8 +141,7 @@ func (u *userRepository) TransactionUpdate(tx *gorm.DB, user *dbmodel.Users, upd
 		user.AllowNotify = updateData.AllowNotify
 	}
 	if err := tx.Save(user).Error; err != nil {
-		log.Printf("error when save user: %v\n", err)
-		return nil, err
+		return nil, fmt.Errorf("saving user: %w", err)
 	}
 	return user, nil
 }
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+)
+
+const (
+	attendeeCacheTTL = 10 * time.Minute
+
+	// attendeeCacheSize bounds the cache, expired entries are dropped first when it is full
+	attendeeCacheSize = 5000
+)
+
+var emailRegex = regexp.MustCompile(constant.EMAIL_REGEX)
+
+// UserAPI is the part of the Mattermost plugin API needed to resolve attendees
+type UserAPI interface {
+	GetUserByEmail(email string) (*model.User, *model.AppError)
+	GetUserByUsername(name string) (*model.User, *model.AppError)
+}
+
+// AttendeeService maps the emails of event attendees to Mattermost users
+type AttendeeService interface {
+	// UserByEmail returns the Mattermost user with the email, or nil when there is none
+	UserByEmail(email string) *model.User
+	// EmailOf turns an attendee given as a Mattermost username, with or without @, or as an email
+	// into an email
+	EmailOf(member string) (string, error)
+}
+
+type attendeeEntry struct {
+	user    *model.User
+	expires time.Time
+}
+
+type attendeeService struct {
+	api   UserAPI
+	mutex sync.Mutex
+	cache map[string]attendeeEntry
+}
+
+func NewAttendeeService(api UserAPI) AttendeeService {
+	return &attendeeService{
+		api:   api,
+		cache: make(map[string]attendeeEntry),
+	}
+}
+
+func (a *attendeeService) UserByEmail(email string) *model.User {
+	key := strings.ToLower(email)
+	now := time.Now()
+
+	a.mutex.Lock()
+	entry, ok := a.cache[key]
+	a.mutex.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.user
+	}
+
+	// emails without an account are cached too, most guests of a meeting are not on Mattermost
+	user, appErr := a.api.GetUserByEmail(email)
+	if appErr != nil {
+		user = nil
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if len(a.cache) >= attendeeCacheSize {
+		for k, e := range a.cache {
+			if !now.Before(e.expires) {
+				delete(a.cache, k)
+			}
+		}
+		if len(a.cache) >= attendeeCacheSize {
+			a.cache = make(map[string]attendeeEntry)
+		}
+	}
+	a.cache[key] = attendeeEntry{user: user, expires: now.Add(attendeeCacheTTL)}
+	return user
+}
+
+func (a *attendeeService) EmailOf(member string) (string, error) {
+	// come from email address
+	if !strings.HasPrefix(member, "@") && emailRegex.MatchString(strings.ToLower(member)) {
+		return member, nil
+	}
+
+	// come from @mention or username in mattermost
+	username := strings.TrimPrefix(member, "@")
+	user, appErr := a.api.GetUserByUsername(username)
+	if appErr != nil {
+		if strings.Contains(member, "@") && !strings.HasPrefix(member, "@") {
+			return "", fmt.Errorf("invalid email address: %s", member)
+		}
+		return "", fmt.Errorf("username %s not found", username)
+	}
+	return user.Email, nil
+}
@@ -1,8 +1,6 @@
 package service
 
 import (
-	"fmt"
-
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/repository"
 )
@@ -28,7 +26,6 @@ func (c *connectStateService) Create(userID string, state string) error {
 		UserID: userID,
 		State:  state,
 	}); err != nil {
-		fmt.Printf("Error when insert connect state: %v", err)
 		return err
 	}
 	return nil
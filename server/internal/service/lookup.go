@@ -2,18 +2,18 @@ package service
 
 import (
 	"errors"
-	"fmt"
 
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/repository"
-	"gorm.io/gorm"
 )
 
 type LookupService interface {
 	Set(models.Lookups) error
 	Get(models.LookupsRequest) (*models.LookupsResponse, error)
 	Delete(models.LookupsRequest) error
+	DeleteKeys(string, []string) error
 	DeleteAllForUser(string) error
 }
 
@@ -34,28 +34,21 @@ func (l *lookupService) Set(lookup models.Lookups) error {
 		Key:    lookup.Key,
 	})
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
+		if errors.Is(err, apperr.ErrNotFound) {
 			// insert lookup
-			if err := l.lookupRepository.Insert(dbmodel.Lookups(lookup)); err != nil {
-				fmt.Printf("error getting lookup inserting: %v\n", err)
-				return err
-			}
-			return nil
+			return l.lookupRepository.Insert(dbmodel.Lookups(lookup))
 		}
+		return err
 	}
 
 	// update lookup
 	res.Value = lookup.Value
-	if err := l.lookupRepository.Update(dbmodel.Lookups(*res)); err != nil {
-		fmt.Printf("error getting lookup updating: %v\n", err)
-		return err
-	}
-	return nil
+	return l.lookupRepository.Update(dbmodel.Lookups(*res))
 }
 
 func (l *lookupService) Get(lookupRequest models.LookupsRequest) (*models.LookupsResponse, error) {
 	if !lookupRequest.IsValid() {
-		return nil, errors.New("invalid lookup request")
+		return nil, apperr.Validation("", "invalid lookup request")
 	}
 
 	lookupResponse, err := l.lookupRepository.Get(lookupRequest)
@@ -75,6 +68,13 @@ func (l *lookupService) Delete(lookup models.LookupsRequest) error {
 	return nil
 }
 
+func (l *lookupService) DeleteKeys(userID string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return l.lookupRepository.DeleteKeys(userID, keys)
+}
+
 func (l *lookupService) DeleteAllForUser(userID string) error {
 	if err := l.lookupRepository.DeleteAllForUser(userID); err != nil {
 		return err
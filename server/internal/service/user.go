@@ -3,11 +3,12 @@ package service
 import (
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/helper"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models/dbmodel"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/repository"
@@ -37,31 +38,36 @@ func NewUserService(db *gorm.DB, userRepo repository.UserRepository, lookupRepo
 	}
 }
 
+// notConnected turns a user that isn't found into apperr.ErrNotConnected
+func notConnected(err error) error {
+	if errors.Is(err, apperr.ErrNotFound) {
+		return apperr.ErrNotConnected
+	}
+	return err
+}
+
 func (u *userService) UpsertUserToken(user models.UpsertUser) (*models.UserDataDto, bool, error) {
 	isUpdate := false
 	if user.UserID == "" || user.CalendarToken == "" {
-		return nil, isUpdate, errors.New("invalid user id or calendar token")
+		return nil, isUpdate, apperr.Validation("", "invalid user id or calendar token")
 	}
 
 	// is user exist?
 	currUser, err := u.userRepo.FindByUserID(user.UserID)
-	if err != nil {
-		log.Println("error when find user by id", "err", err.Error())
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
 		return nil, isUpdate, err
 	}
 
 	token, err := helper.Decrypt(user.CalendarToken, user.EncryptSecret)
 	if err != nil {
-		log.Println("error when decrypt calendar token", "err", err.Error())
-		return nil, isUpdate, err
+		return nil, isUpdate, fmt.Errorf("decrypting calendar token: %w", err)
 	}
 	now := time.Now()
 	if currUser == nil {
 		// create new user
 		userSettingStr, err := constant.DefaultUserSettings.String()
 		if err != nil {
-			log.Println("error when convert user settings to string", "err", err.Error())
-			return nil, isUpdate, err
+			return nil, isUpdate, fmt.Errorf("converting user settings: %w", err)
 		}
 		userToCreate := dbmodel.Users{
 			ID:            user.UserID,
@@ -75,7 +81,6 @@ func (u *userService) UpsertUserToken(user models.UpsertUser) (*models.UserDataD
 
 		createdUser, err := u.userRepo.Create(userToCreate)
 		if err != nil {
-			log.Println("error when create user", "err", err)
 			return nil, isUpdate, err
 		}
 		return &models.UserDataDto{
@@ -97,14 +102,12 @@ func (u *userService) UpsertUserToken(user models.UpsertUser) (*models.UserDataD
 	// update
 	updatedUser, err := u.userRepo.Update(currUser, &userToUpdate)
 	if err != nil {
-		log.Println("error when update calendar token", "err", err.Error())
 		return nil, isUpdate, err
 	}
 	userSetting := models.UserSettings{}
 	if helper.IsJSON(updatedUser.Settings) {
 		if err := json.Unmarshal([]byte(updatedUser.Settings), &userSetting); err != nil {
-			log.Println("error when unmarshal user setting", "err", err.Error())
-			return nil, isUpdate, err
+			return nil, isUpdate, fmt.Errorf("unmarshalling user settings: %w", err)
 		}
 	}
 	return &models.UserDataDto{
@@ -119,22 +122,17 @@ func (u *userService) UpsertUserToken(user models.UpsertUser) (*models.UserDataD
 func (u *userService) GetUserByID(userID string, secret string) (models.UserDataDto, error) {
 	user, err := u.userRepo.FindByUserID(userID)
 	if err != nil {
-		return models.UserDataDto{}, err
-	}
-	if user == nil {
-		return models.UserDataDto{}, errors.New(constant.INTERNAL_ERR_USER_NOT_FOUND)
+		return models.UserDataDto{}, notConnected(err)
 	}
 	userSetting := models.UserSettings{}
 	if helper.IsJSON(user.Settings) {
 		if err := json.Unmarshal([]byte(user.Settings), &userSetting); err != nil {
-			log.Println("error when unmarshal user setting", "err", err.Error())
-			return models.UserDataDto{}, err
+			return models.UserDataDto{}, fmt.Errorf("unmarshalling user settings: %w", err)
 		}
 	}
 	token, err := helper.Decrypt(user.CalendarToken, secret)
 	if err != nil {
-		log.Println("error when decrypt calendar token", "err", err.Error())
-		return models.UserDataDto{}, err
+		return models.UserDataDto{}, fmt.Errorf("decrypting calendar token: %w", err)
 	}
 	return models.UserDataDto{
 		UserID:        user.ID,
@@ -148,10 +146,7 @@ func (u *userService) GetUserByID(userID string, secret string) (models.UserData
 func (u *userService) UpdateUserSetting(userID string, newUserSetting models.UserSettings) error {
 	user, err := u.userRepo.FindByUserID(userID)
 	if err != nil {
-		return err
-	}
-	if user == nil {
-		return errors.New(constant.INTERNAL_ERR_USER_NOT_FOUND)
+		return notConnected(err)
 	}
 
 	userSettingString, err := newUserSetting.String()
@@ -171,10 +166,7 @@ func (u *userService) UpdateUserSetting(userID string, newUserSetting models.Use
 func (u *userService) UpdateUser(userID string, updateData models.UpdateUser) error {
 	user, err := u.userRepo.FindByUserID(userID)
 	if err != nil {
-		return err
-	}
-	if user == nil {
-		return errors.New(constant.INTERNAL_ERR_USER_NOT_FOUND)
+		return notConnected(err)
 	}
 
 	userSettingString, err := updateData.Setting.String()
@@ -195,12 +187,8 @@ func (u *userService) UpdateUser(userID string, updateData models.UpdateUser) er
 }
 
 func (u *userService) DeleteUserData(userID string) error {
-	user, err := u.userRepo.FindByUserID(userID)
-	if err != nil {
-		return err
-	}
-	if user == nil {
-		return errors.New(constant.INTERNAL_ERR_USER_NOT_FOUND)
+	if _, err := u.userRepo.FindByUserID(userID); err != nil {
+		return notConnected(err)
 	}
 
 	if err := u.userRepo.Delete(userID); err != nil {
@@ -212,7 +200,7 @@ func (u *userService) DeleteUserData(userID string) error {
 
 func (u *userService) List(secret string, opts models.ListUsersOption) (models.ListUsersResult, error) {
 	result, err := u.userRepo.List(opts)
-	if err != nil || result == nil {
+	if err != nil {
 		return models.ListUsersResult{}, err
 	}
 	users, ok := result.Rows.([]dbmodel.Users)
@@ -225,14 +213,12 @@ func (u *userService) List(secret string, opts models.ListUsersOption) (models.L
 		setting := models.UserSettings{}
 		if helper.IsJSON(user.Settings) {
 			if err := json.Unmarshal([]byte(user.Settings), &setting); err != nil {
-				log.Println("error when unmarshal user config", "err", err.Error())
-				return models.ListUsersResult{}, err
+				return models.ListUsersResult{}, fmt.Errorf("unmarshalling settings of user %s: %w", user.ID, err)
 			}
 		}
 		token, err := helper.Decrypt(user.CalendarToken, secret)
 		if err != nil {
-			log.Println("error when decrypt calendar token", "err", err.Error())
-			return models.ListUsersResult{}, err
+			return models.ListUsersResult{}, fmt.Errorf("decrypting calendar token of user %s: %w", user.ID, err)
 		}
 		userDataDtos = append(userDataDtos, models.UserDataDto{
 			UserID:        user.ID,
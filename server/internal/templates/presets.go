@@ -0,0 +1,117 @@
+package templates
+
+// presets are the builtin sets of templates. Detailed is how the bot has always written, compact
+// keeps every post to a few lines and attachment puts the details of the event in a quote, like a
+// card under its title. When the event has a card of its own, they only print what the card
+// doesn't show
+var presets = map[string]map[string]string{
+	PresetDetailed:   detailed,
+	PresetCompact:    compact,
+	PresetAttachment: attachment,
+}
+
+var detailed = map[string]string{
+	EventTemplate: `{{if not .Card -}}
+{{"\n"}}**[{{.Title}}]({{.Link}})**{{"\n" -}}
+{{.T "event.when" .When -}}
+{{with .Where}}{{$.T "event.where" .}}{{end -}}
+{{.Guests -}}
+{{.T "event.status" .Status -}}
+{{with .Going}}{{.}}{{"\n"}}{{end -}}
+{{with .DeleteLink}}{{$.T "event.delete" .}}{{end -}}
+{{end -}}
+`,
+
+	InviteTemplate: `{{.T "changes.invited"}}{{template "event" .Event}}{{.Conflicts}}`,
+
+	UpdateTemplate: `{{.T "event.updated.title"}}{{"\n" -}}
+{{if .TitleChanged}}**~~[{{.Old.Title}}]({{.Old.Link}})~~** ⟶ {{end}}**[{{.Event.Title}}]({{.Event.Link}})**{{"\n" -}}
+{{if .TimeChanged}}{{.T "event.when" (change .Old.When .Event.When)}}{{else if not .Event.Card}}{{.T "event.when" .Event.When}}{{end -}}
+{{if .RecurrenceChanged}}{{.T "event.repeats" (change .Old.Repeats .Event.Repeats)}}{{end -}}
+{{if .LocationChanged}}{{.T "event.where" (change .Old.Location .Event.Location)}}
+{{- else if and .Event.Location (not .Event.Card)}}{{.T "event.where" .Event.Location}}{{end -}}
+{{if .MeetChanged -}}
+	{{if not .Old.Meet}}{{.T "event.meet.added" .Event.Meet}}
+	{{- else if not .Event.Meet}}{{.T "event.meet.removed" .Old.Meet}}
+	{{- else}}{{.T "event.meet" (change .Old.Meet .Event.Meet)}}{{end -}}
+{{end -}}
+{{if .DescriptionChanged}}{{.T "event.description" .DescriptionDiff}}{{end -}}
+{{with .AddedGuests}}{{$.T "event.guests.added" .}}{{end -}}
+{{with .RemovedGuests}}{{$.T "event.guests.removed" .}}{{end -}}
+{{if not (or .AddedGuests .RemovedGuests .Event.Card)}}{{.Event.Guests}}{{end -}}
+{{range .Responses}}- {{.}}{{"\n"}}{{end -}}
+{{if .StatusChanged}}{{.T "event.status" (change .Old.Status .Event.Status)}}
+{{- else if not .Event.Card}}{{.T "event.status" .Event.Status}}{{end -}}
+{{if not .Event.Card}}{{with .Event.Going}}{{.}}{{"\n\n"}}{{end}}{{end -}}
+`,
+
+	CancelTemplate: `{{.T "changes.cancelled" .Event.Title .Event.Link .Event.When}}`,
+
+	ReminderTemplate: `{{if .Snoozed}}{{.T "reminder.snoozed"}}{{else if .AllDay}}{{.T "reminder.all_day"}}{{else}}{{.N "reminder.minutes" .MinutesBefore}}{{end}}{{"\n\n" -}}
+{{template "event" .Event}}`,
+}
+
+var compact = map[string]string{
+	EventTemplate: `{{if not .Card -}}
+{{"\n"}}**[{{.Title}}]({{.Link}})** · {{.When}}{{with .Where}} · {{.}}{{end}}{{"\n" -}}
+{{with .Going}}{{.}}{{"\n"}}{{end -}}
+{{end -}}
+`,
+
+	InviteTemplate: `{{.T "changes.invited"}}{{template "event" .Event}}{{.Conflicts}}`,
+
+	UpdateTemplate: `{{.T "event.updated.title"}}{{"\n" -}}
+{{if .TitleChanged}}~~{{.Old.Title}}~~ ⟶ {{end}}**[{{.Event.Title}}]({{.Event.Link}})**{{"\n" -}}
+{{if .TimeChanged}}{{.T "event.when" (change .Old.When .Event.When)}}{{end -}}
+{{if .LocationChanged}}{{.T "event.where" (change .Old.Location .Event.Location)}}{{end -}}
+{{if .StatusChanged}}{{.T "event.status" .Event.Status}}{{end -}}
+{{if not .Event.Card}}{{with .Event.Going}}{{.}}{{"\n"}}{{end}}{{end -}}
+`,
+
+	CancelTemplate: `{{.T "changes.cancelled" .Event.Title .Event.Link .Event.When}}`,
+
+	ReminderTemplate: `{{if .Snoozed}}{{.T "reminder.snoozed"}}{{else if .AllDay}}{{.T "reminder.all_day"}}{{else}}{{.N "reminder.minutes" .MinutesBefore}}{{end -}}
+{{template "event" .Event}}`,
+}
+
+var attachment = map[string]string{
+	EventTemplate: `{{define "card" -}}
+{{.T "event.when" .When -}}
+{{with .Where}}{{$.T "event.where" .}}{{end -}}
+{{with .Description}}{{$.T "event.description" .}}{{end -}}
+{{.Guests -}}
+{{.T "event.status" .Status -}}
+{{end -}}
+{{if not .Card -}}
+{{"\n"}}#### [{{.Title}}]({{.Link}}){{"\n" -}}
+{{quote (include "card" .)}}{{"\n\n" -}}
+{{with .Going}}{{.}}{{"\n"}}{{end -}}
+{{with .DeleteLink}}{{$.T "event.delete" .}}{{end -}}
+{{end -}}
+`,
+
+	InviteTemplate: `{{.T "changes.invited"}}{{template "event" .Event}}{{with .Conflicts}}{{"\n"}}{{quote .}}{{"\n"}}{{end}}`,
+
+	UpdateTemplate: `{{.T "event.updated.title"}}{{"\n" -}}
+#### {{if .TitleChanged}}~~{{.Old.Title}}~~ ⟶ {{end}}[{{.Event.Title}}]({{.Event.Link}}){{"\n" -}}
+{{define "changes" -}}
+{{if .TimeChanged}}{{.T "event.when" (change .Old.When .Event.When)}}{{else if not .Event.Card}}{{.T "event.when" .Event.When}}{{end -}}
+{{if .RecurrenceChanged}}{{.T "event.repeats" (change .Old.Repeats .Event.Repeats)}}{{end -}}
+{{if .LocationChanged}}{{.T "event.where" (change .Old.Location .Event.Location)}}
+{{- else if and .Event.Location (not .Event.Card)}}{{.T "event.where" .Event.Location}}{{end -}}
+{{if .DescriptionChanged}}{{.T "event.description" .DescriptionDiff}}{{end -}}
+{{with .AddedGuests}}{{$.T "event.guests.added" .}}{{end -}}
+{{with .RemovedGuests}}{{$.T "event.guests.removed" .}}{{end -}}
+{{range .Responses}}- {{.}}{{"\n"}}{{end -}}
+{{if .StatusChanged}}{{.T "event.status" (change .Old.Status .Event.Status)}}
+{{- else if not .Event.Card}}{{.T "event.status" .Event.Status}}{{end -}}
+{{end -}}
+{{with include "changes" .}}{{quote .}}{{"\n\n"}}{{end -}}
+{{if not .Event.Card}}{{with .Event.Going}}{{.}}{{"\n\n"}}{{end}}{{end -}}
+`,
+
+	CancelTemplate: `{{.T "changes.cancelled" .Event.Title .Event.Link .Event.When}}`,
+
+	ReminderTemplate: `{{if .Snoozed}}{{.T "reminder.snoozed"}}{{else if .AllDay}}{{.T "reminder.all_day"}}{{else}}{{.N "reminder.minutes" .MinutesBefore}}{{end}}{{"\n" -}}
+{{template "event" .Event}}`,
+}
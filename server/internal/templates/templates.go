@@ -0,0 +1,329 @@
+// Package templates renders the posts of the bot about events from named text/template
+// templates. Every preset defines the templates below, and the administrators can override any of
+// them in the configuration of the plugin.
+//
+//	event     an event on its own, used by the summaries, the agenda links and the other templates. Data: Event
+//	invite    the user has been invited to an event. Data: Invite
+//	update    an event the user is going to has changed. Data: Update
+//	cancel    an event the user was going to has been cancelled. Data: Cancel
+//	reminder  an event is about to start. Data: Reminder
+//
+// Templates call each other with {{template "event" .Event}} and can define their own templates
+// with {{define}}. Texts are translated in the language of the viewer with {{.T "message.id" args}},
+// and {{.N "message.id" count args}} for the messages with a plural. Besides the builtin functions,
+// the templates can use:
+//
+//	change OLD NEW     strikes through the old value and shows the new one
+//	quote TEXT         quotes every line of the text
+//	include NAME DATA  renders another template to a string, to pass it to a function
+//	join LIST SEP      joins a list of strings
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+)
+
+// Names of the templates
+const (
+	EventTemplate    = "event"
+	InviteTemplate   = "invite"
+	UpdateTemplate   = "update"
+	CancelTemplate   = "cancel"
+	ReminderTemplate = "reminder"
+)
+
+// Names of the presets
+const (
+	PresetDetailed   = "detailed"
+	PresetCompact    = "compact"
+	PresetAttachment = "attachment"
+)
+
+// DefaultPreset is used when the configuration doesn't choose one
+const DefaultPreset = PresetDetailed
+
+// Names returns the names of the templates
+func Names() []string {
+	return []string{EventTemplate, InviteTemplate, UpdateTemplate, CancelTemplate, ReminderTemplate}
+}
+
+// Localized gives the templates the messages in the language of the viewer
+type Localized struct {
+	Localizer *i18n.Localizer
+}
+
+// T translates a message
+func (l Localized) T(id string, args ...interface{}) string {
+	return l.Localizer.T(id, args...)
+}
+
+// N translates a message in the plural form matching count
+func (l Localized) N(id string, count int, args ...interface{}) string {
+	return l.Localizer.N(id, count, args...)
+}
+
+// GuestCounts are how many guests answered what, the organizer is not counted
+type GuestCounts struct {
+	Yes      int
+	No       int
+	Maybe    int
+	Awaiting int
+}
+
+// Event is an event as seen by the viewer. Texts are already formatted and translated
+type Event struct {
+	Localized
+	ID    string
+	Title string
+	Link  string
+
+	// When is the time of the event in the viewer's timezone, like "Today @ 9:00 AM UTC to 10:00 AM UTC"
+	When string
+
+	// Location is the location set on the event, Meet its Google Meet link and Where the location
+	// or else the Meet link
+	Location string
+	Meet     string
+	Where    string
+
+	Description string
+
+	// Repeats lists the recurrence rules of the event
+	Repeats string
+
+	// Status is the translated status of the event, like Confirmed
+	Status string
+
+	// Guests lists the organizer and the guests with their responses, one line each
+	Guests      string
+	GuestCounts GuestCounts
+
+	// Response is the answer of the viewer, accepted, declined, tentative or needsAction, and empty
+	// when they are not a guest. Going prints it, with the links to answer when it is needsAction,
+	// and is empty when there is nothing to answer
+	Response string
+	Going    string
+
+	// Organizer tells whether the viewer organizes the event, DeleteLink deletes it
+	Organizer  bool
+	DeleteLink string
+
+	// Card tells that the event is shown in a card under the post, with its time, place, guests
+	// and the buttons to answer, so the template only needs to say what the card doesn't
+	Card bool
+}
+
+// Invite is the data of the invite template
+type Invite struct {
+	Localized
+	Event Event
+
+	// Conflicts warns about the events overlapping this one, empty when there are none
+	Conflicts string
+}
+
+// Update is the data of the update template. Old is the event before the change
+type Update struct {
+	Localized
+	Event Event
+	Old   Event
+
+	TitleChanged       bool
+	TimeChanged        bool
+	RecurrenceChanged  bool
+	LocationChanged    bool
+	MeetChanged        bool
+	DescriptionChanged bool
+	StatusChanged      bool
+
+	// DescriptionDiff shows the lines added to and removed from the description
+	DescriptionDiff string
+
+	// AddedGuests and RemovedGuests list the guests invited or removed, Responses the guests who
+	// answered since the last sync
+	AddedGuests   string
+	RemovedGuests string
+	Responses     []string
+}
+
+// Cancel is the data of the cancel template
+type Cancel struct {
+	Localized
+	Event Event
+}
+
+// Reminder is the data of the reminder template. All-day events are reminded on their first day,
+// the other ones MinutesBefore minutes before they start. Snoozed reminders come back when the
+// user asked for them
+type Reminder struct {
+	Localized
+	Event         Event
+	AllDay        bool
+	MinutesBefore int
+	Snoozed       bool
+}
+
+// Set is a parsed set of templates
+type Set struct {
+	root *template.Template
+}
+
+// defaultSet renders the posts when the configured templates can't
+var defaultSet = Must(New(DefaultPreset, nil))
+
+// Default returns the templates of the default preset
+func Default() *Set {
+	return defaultSet
+}
+
+// Must panics when the templates couldn't be parsed, it is meant for the builtin presets
+func Must(set *Set, err error) *Set {
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+// New parses the templates of the preset, replaced by the overrides. Every template is executed on
+// sample data, so the errors of the administrators are found when they save the configuration
+func New(preset string, overrides map[string]string) (*Set, error) {
+	if preset == "" {
+		preset = DefaultPreset
+	}
+	texts, ok := presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown preset %q, the presets are %s", preset, strings.Join(Presets(), ", "))
+	}
+
+	set := &Set{}
+	set.root = template.New("").Funcs(template.FuncMap{
+		"change":  change,
+		"quote":   quote,
+		"join":    strings.Join,
+		"include": set.include,
+	})
+	for _, name := range Names() {
+		text := texts[name]
+		if override, ok := overrides[name]; ok {
+			text = override
+		}
+		if _, err := set.root.New(name).Parse(text); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+	for name := range overrides {
+		if _, ok := texts[name]; !ok {
+			return nil, fmt.Errorf("unknown template %q, the templates are %s", name, strings.Join(Names(), ", "))
+		}
+	}
+
+	if err := set.validate(); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// Presets returns the names of the builtin presets
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Execute renders the template with the data
+func (s *Set) Execute(name string, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := s.root.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (s *Set) include(name string, data interface{}) (string, error) {
+	return s.Execute(name, data)
+}
+
+// validate executes every template on sample data, once with everything set and once with as
+// little as possible, so both sides of the conditions are checked
+func (s *Set) validate() error {
+	full, bare := sampleEvent(), Event{Localized: sampleEvent().Localized}
+	card := full
+	card.Card = true
+	old := full
+	old.Title, old.When, old.Location, old.Meet, old.Status = "Planning", "Yesterday", "", "", "Tentative"
+
+	samples := []struct {
+		name string
+		data interface{}
+	}{
+		{EventTemplate, full},
+		{EventTemplate, bare},
+		{EventTemplate, card},
+		{InviteTemplate, Invite{Localized: full.Localized, Event: full, Conflicts: "**:warning: Conflicts with:**\n"}},
+		{InviteTemplate, Invite{Localized: bare.Localized, Event: bare}},
+		{UpdateTemplate, Update{
+			Localized: full.Localized, Event: full, Old: old,
+			TitleChanged: true, TimeChanged: true, RecurrenceChanged: true, LocationChanged: true,
+			MeetChanged: true, DescriptionChanged: true, StatusChanged: true,
+			DescriptionDiff: "~~Agenda~~ Agenda of the week", AddedGuests: "@guest", RemovedGuests: "@former",
+			Responses: []string{"@guest accepted"},
+		}},
+		{UpdateTemplate, Update{Localized: bare.Localized, Event: bare, Old: bare}},
+		{UpdateTemplate, Update{Localized: card.Localized, Event: card, Old: full}},
+		{CancelTemplate, Cancel{Localized: full.Localized, Event: full}},
+		{ReminderTemplate, Reminder{Localized: full.Localized, Event: full, AllDay: true}},
+		{ReminderTemplate, Reminder{Localized: bare.Localized, Event: bare, MinutesBefore: 10}},
+		{ReminderTemplate, Reminder{Localized: card.Localized, Event: card, Snoozed: true}},
+	}
+	for _, sample := range samples {
+		if _, err := s.Execute(sample.name, sample.data); err != nil {
+			return fmt.Errorf("template %s: %w", sample.name, err)
+		}
+	}
+	return nil
+}
+
+func sampleEvent() Event {
+	return Event{
+		Localized:   Localized{Localizer: i18n.NewLocalizer(i18n.DefaultLocale)},
+		ID:          "event",
+		Title:       "Weekly planning",
+		Link:        "https://www.google.com/calendar/event?eid=event",
+		When:        "Today @ 9:00 AM UTC to 10:00 AM UTC",
+		Location:    "Meeting room",
+		Meet:        "https://meet.google.com/abc-defg-hij",
+		Where:       "Meeting room",
+		Description: "Agenda of the week",
+		Repeats:     "`FREQ=WEEKLY`",
+		Status:      "Confirmed",
+		Guests:      "**Organizer**: @organizer\n",
+		GuestCounts: GuestCounts{Yes: 1},
+		Organizer:   true,
+		Response:    "needsAction",
+		Going:       "**Going?**: [Yes](#) | [No](#) | [Maybe](#)",
+		DeleteLink:  "#",
+	}
+}
+
+// change strikes through the old value of a field and shows the new one
+func change(oldValue, newValue string) string {
+	return fmt.Sprintf("~~%s~~ ⟶ %s", oldValue, newValue)
+}
+
+// quote quotes every line of the text in markdown
+func quote(text string) string {
+	text = strings.Trim(text, "\n")
+	if text == "" {
+		return ""
+	}
+	return "> " + strings.ReplaceAll(text, "\n", "\n> ")
+}
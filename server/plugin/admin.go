@@ -0,0 +1,361 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	// watchExpiringSoon is how close to its expiration a watch channel is reported
+	watchExpiringSoon = 24 * time.Hour
+
+	// maxListedSyncErrors is how many users with a failing sync are listed in the stats
+	maxListedSyncErrors = 10
+)
+
+const adminHelp = `###### Google Calendar Plugin - Admin Commands
+* |/calendar admin stats| - Connected users, users with notifications, watch channels expiring soon and the last sync errors.
+* |/calendar admin user @user| - Connection, last sync and token health of a user.
+* |/calendar admin resync @user| or |all| - Drop the synced events and do a full sync again.
+* |/calendar admin disconnect @user| - Disconnect the Google Calendar of a user.
+* |/calendar admin renew-watches| - Set up again the push notification channels of every user.
+
+Metrics in the Prometheus text format are served to system admins at |/plugins/sshindanai.google-calendar-plugin/metrics|.
+`
+
+// recordSyncResult keeps when the user's calendar was last synced and the last error
+func (p *Plugin) recordSyncResult(userID string, syncErr error) {
+	status := p.getSyncStatus(userID)
+	now := time.Now()
+	if syncErr != nil {
+		status.LastError = syncErr.Error()
+		status.LastErrorAt = now
+	} else {
+		status.LastSync = now
+		status.FailureNotified = false
+	}
+	p.setSyncStatus(userID, status)
+}
+
+func (p *Plugin) setSyncStatus(userID string, status models.SyncStatus) {
+	value, err := json.Marshal(status)
+	if err != nil {
+		p.API.LogError("Error marshalling sync status", "err", err.Error())
+		return
+	}
+	if err := p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.SYNC_STATUS_KEY,
+		Value:  string(value),
+	}); err != nil {
+		p.API.LogError("Error setting sync status", "err", err.Error())
+	}
+}
+
+func (p *Plugin) getSyncStatus(userID string) models.SyncStatus {
+	var status models.SyncStatus
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.SYNC_STATUS_KEY,
+	})
+	if err != nil {
+		return status
+	}
+	if err := json.Unmarshal([]byte(lookup.Value), &status); err != nil {
+		p.API.LogError("Error unmarshalling sync status", "err", err.Error())
+	}
+	return status
+}
+
+// getWatchChannel returns the push notification channel of the user's calendar
+func (p *Plugin) getWatchChannel(userID string) (*calendar.Channel, error) {
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.WATCH_CHANNEL_KEY,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var channel calendar.Channel
+	if err := json.Unmarshal([]byte(lookup.Value), &channel); err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// listUsers returns every connected user
+func (p *Plugin) listUsers() ([]models.UserDataDto, error) {
+	var users []models.UserDataDto
+	secret := p.getConfiguration().EncryptionSecret
+	for page := 1; ; page++ {
+		result, err := p.services.userService.List(secret, models.ListUsersOption{
+			Page:  page,
+			Limit: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Users) == 0 {
+			return users, nil
+		}
+		users = append(users, result.Users...)
+	}
+}
+
+func printAdminTime(t time.Time) string {
+	if t.IsZero() {
+		return "Never"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.UTC().Format(time.RFC1123), time.Since(t).Round(time.Minute))
+}
+
+func (p *Plugin) executeCommandAdmin(args *model.CommandArgs) string {
+	if !p.API.HasPermissionTo(args.UserId, model.PERMISSION_MANAGE_SYSTEM) {
+		return "Only system admins can use the admin commands."
+	}
+
+	split := strings.Fields(args.Command)
+	var action, target string
+	if len(split) > 2 {
+		action = split[2]
+	}
+	if len(split) > 3 {
+		target = split[3]
+	}
+
+	switch action {
+	case "stats":
+		return p.executeAdminStats()
+	case "user":
+		return p.executeAdminUser(target)
+	case "resync":
+		return p.executeAdminResync(args.UserId, target)
+	case "disconnect":
+		return p.executeAdminDisconnect(target)
+	case "renew-watches":
+		return p.executeAdminRenewWatches(args.UserId)
+	default:
+		return strings.Replace(adminHelp, "|", "`", -1)
+	}
+}
+
+// getAdminTarget returns the connected user given as @username
+func (p *Plugin) getAdminTarget(target string) (*model.User, models.UserDataDto, error) {
+	if target == "" {
+		return nil, models.UserDataDto{}, fmt.Errorf("missing user, please give a @username")
+	}
+	username := strings.TrimPrefix(target, "@")
+	mmUser, appErr := p.API.GetUserByUsername(username)
+	if appErr != nil {
+		return nil, models.UserDataDto{}, fmt.Errorf("username %s not found", username)
+	}
+	user, err := p.services.userService.GetUserByID(mmUser.Id, p.getConfiguration().EncryptionSecret)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			return mmUser, user, fmt.Errorf("@%s has not connected their Google Calendar", username)
+		}
+		return mmUser, user, err
+	}
+	return mmUser, user, nil
+}
+
+func (p *Plugin) executeAdminStats() string {
+	users, err := p.listUsers()
+	if err != nil {
+		p.API.LogError("Error listing users", "err", err.Error())
+		return "Unable to list the users"
+	}
+
+	notifying, expiring, missing := 0, 0, 0
+	var failing []string
+	for _, user := range users {
+		if user.AllowNotify == constant.ALLOW_NOTIFY {
+			notifying++
+		}
+
+		channel, err := p.getWatchChannel(user.UserID)
+		if err != nil {
+			missing++
+		} else if time.Until(time.Unix(0, channel.Expiration*int64(time.Millisecond))) < watchExpiringSoon {
+			expiring++
+		}
+
+		status := p.getSyncStatus(user.UserID)
+		if status.LastErrorAt.After(status.LastSync) && len(failing) < maxListedSyncErrors {
+			username := user.UserID
+			if mmUser, appErr := p.API.GetUser(user.UserID); appErr == nil {
+				username = "@" + mmUser.Username
+			}
+			failing = append(failing, fmt.Sprintf("- %s, %s: `%s`", username, printAdminTime(status.LastErrorAt), status.LastError))
+		}
+	}
+
+	text := "#### Google Calendar Plugin Stats\n"
+	text += fmt.Sprintf("**Connected users**: %d\n", len(users))
+	text += fmt.Sprintf("**Users with notifications**: %d\n", notifying)
+	text += fmt.Sprintf("**Watch channels expiring within %s**: %d\n", watchExpiringSoon, expiring)
+	text += fmt.Sprintf("**Users without a watch channel**: %d\n", missing)
+	text += fmt.Sprintf("**Startup syncs waiting for a retry**: %d\n", atomic.LoadInt32(&p.pendingSyncRetries))
+	if len(failing) == 0 {
+		text += "**Last sync errors**: None\n"
+	} else {
+		text += "**Last sync errors**:\n" + strings.Join(failing, "\n") + "\n"
+	}
+	return text
+}
+
+func (p *Plugin) executeAdminUser(target string) string {
+	mmUser, user, err := p.getAdminTarget(target)
+	if err != nil {
+		return err.Error()
+	}
+
+	text := fmt.Sprintf("#### @%s\n", mmUser.Username)
+	text += fmt.Sprintf("**Google account**: %s\n", user.Email)
+	text += fmt.Sprintf("**Notifications**: %s\n", HandleAllowNotiAtoBoolString(user.AllowNotify))
+
+	status := p.getSyncStatus(user.UserID)
+	text += fmt.Sprintf("**Last sync**: %s\n", printAdminTime(status.LastSync))
+	if status.LastError != "" {
+		text += fmt.Sprintf("**Last sync error**: %s: `%s`\n", printAdminTime(status.LastErrorAt), status.LastError)
+	}
+
+	if channel, err := p.getWatchChannel(user.UserID); err != nil {
+		text += "**Watch channel**: None\n"
+	} else {
+		expiration := time.Unix(0, channel.Expiration*int64(time.Millisecond))
+		text += fmt.Sprintf("**Watch channel**: expires %s\n", expiration.UTC().Format(time.RFC1123))
+	}
+
+	text += fmt.Sprintf("**Token**: %s\n", p.checkTokenHealth(user))
+	if since := p.tokenInvalidSince(user.UserID); !since.IsZero() {
+		text += fmt.Sprintf("**Token refused by Google**: %s\n", printAdminTime(since))
+	}
+	if calendars, err := p.getCalendarList(user.UserID); err == nil {
+		text += fmt.Sprintf("**Calendars**: %d\n", len(calendars))
+	}
+	return text
+}
+
+// checkTokenHealth tells whether the stored token can still be used to call Google
+func (p *Plugin) checkTokenHealth(user models.UserDataDto) string {
+	var token oauth2.Token
+	if err := json.Unmarshal([]byte(user.CalendarToken), &token); err != nil {
+		return "Invalid, the user must connect again"
+	}
+	var health string
+	if token.RefreshToken == "" {
+		health = "No refresh token, "
+	}
+
+	// the service is created again so the stored token is the one tested
+	cal, err := p.newUserCalendarService(user)
+	if err != nil {
+		return health + "unable to create the calendar service: " + err.Error()
+	}
+	if _, err := cal.service.Calendars.Get(constant.PRIMARY_CALENDAR_ID).Do(); err != nil {
+		err = apperr.FromGoogle(err)
+		if errors.Is(err, apperr.ErrTokenRevoked) {
+			return health + fmt.Sprintf("rejected by Google, the user must connect again (`%s`)", err.Error())
+		}
+		return health + fmt.Sprintf("unable to call Google (`%s`)", err.Error())
+	}
+	return health + "OK"
+}
+
+// resyncUser drops the synced events of the user and does a full sync
+func (p *Plugin) resyncUser(ctx context.Context, user models.UserDataDto) error {
+	if err := p.dropStoredEvents(user.UserID); err != nil {
+		return err
+	}
+	return p.CalendarSyncV2(ctx, user)
+}
+
+func (p *Plugin) executeAdminResync(adminID, target string) string {
+	if target != "all" {
+		mmUser, user, err := p.getAdminTarget(target)
+		if err != nil {
+			return err.Error()
+		}
+		if err := p.resyncUser(p.ctx, user); err != nil {
+			p.API.LogError("Error resyncing user", "err", err.Error(), "userID", user.UserID)
+			return fmt.Sprintf("Unable to sync the calendar of @%s: `%s`", mmUser.Username, err.Error())
+		}
+		return fmt.Sprintf("The calendar of @%s was synced again.", mmUser.Username)
+	}
+
+	go func() {
+		var synced, failed int32
+		p.forEachUser("admin_resync", "", func(user models.UserDataDto) {
+			if err := p.resyncUser(p.ctx, user); err != nil {
+				p.API.LogError("Error resyncing user", "err", err.Error(), "userID", user.UserID)
+				atomic.AddInt32(&failed, 1)
+				return
+			}
+			atomic.AddInt32(&synced, 1)
+		})
+		if appErr := p.CreateBotDMPost(adminID, fmt.Sprintf("Resync done: %d synced, %d failed.", synced, failed)); appErr != nil {
+			p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+		}
+	}()
+	return "Syncing every calendar again, you will get a message when it is done."
+}
+
+func (p *Plugin) executeAdminDisconnect(target string) string {
+	mmUser, user, err := p.getAdminTarget(target)
+	if err != nil {
+		return err.Error()
+	}
+
+	// the watch channel may already be gone, the user data is deleted anyway
+	if err := p.stopWatch(user.UserID); err != nil {
+		p.API.LogError("Error stopping watch", "err", err.Error(), "userID", user.UserID)
+	}
+	p.calendarCache.invalidate(user.UserID)
+	if err := p.removeTeamDigestSubscriptions(user.UserID); err != nil {
+		p.API.LogError("Error removing team digest subscriptions", "err", err.Error(), "userID", user.UserID)
+	}
+	if err := p.services.userService.DeleteUserData(user.UserID); err != nil {
+		p.API.LogError("Error deleting user data", "err", err.Error(), "userID", user.UserID)
+		return fmt.Sprintf("Unable to disconnect @%s", mmUser.Username)
+	}
+
+	if appErr := p.CreateBotDMPost(user.UserID, p.getLocalizer(user.UserID).T("disconnect.by_admin")); appErr != nil {
+		p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+	}
+	return fmt.Sprintf("Disconnected the Google Calendar of @%s.", mmUser.Username)
+}
+
+func (p *Plugin) executeAdminRenewWatches(adminID string) string {
+	go func() {
+		var renewed, failed int32
+		p.forEachUser("admin_renew_watches", "", func(user models.UserDataDto) {
+			if _, err := p.getWatchChannel(user.UserID); err == nil {
+				if err := p.stopWatch(user.UserID); err != nil {
+					p.API.LogError("Error stopping watch", "err", err.Error(), "userID", user.UserID)
+				}
+			}
+			if err := p.setupCalendarWatchV2(user); err != nil {
+				atomic.AddInt32(&failed, 1)
+				return
+			}
+			atomic.AddInt32(&renewed, 1)
+		})
+		if appErr := p.CreateBotDMPost(adminID, fmt.Sprintf("Watch renewal done: %d renewed, %d failed.", renewed, failed)); appErr != nil {
+			p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+		}
+	}()
+	return "Renewing every watch channel, you will get a message when it is done."
+}
@@ -0,0 +1,214 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	// maxAgendaDays keeps a custom range to something that still fits in a few posts
+	maxAgendaDays = 62
+
+	// agendaPostLimit leaves some room for the prefix added by CreateBotDMPost
+	agendaPostLimit = model.POST_MESSAGE_MAX_RUNES_V2 - 100
+)
+
+type agendaDay struct {
+	date   time.Time
+	events []*calendar.Event
+}
+
+// parseAgendaRange turns "this week", "next week" or "YYYY-MM-DD..YYYY-MM-DD" into a [start, end) range
+func parseAgendaRange(input string, now time.Time, format timeFormat) (time.Time, time.Time, string, error) {
+	location := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	// weeks start on Monday
+	offset := (int(today.Weekday()) + 6) % 7
+	beginOfWeek := today.AddDate(0, 0, -offset)
+
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "", "this week":
+		return beginOfWeek, beginOfWeek.AddDate(0, 0, 7), format.T("agenda.range.this_week"), nil
+	case "next week":
+		return beginOfWeek.AddDate(0, 0, 7), beginOfWeek.AddDate(0, 0, 14), format.T("agenda.range.next_week"), nil
+	}
+
+	dates := strings.Split(strings.TrimSpace(input), "..")
+	if len(dates) != 2 {
+		return time.Time{}, time.Time{}, "", errors.New(format.T("agenda.range.invalid"))
+	}
+	start, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, dates[0], location)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", errors.New(format.T("agenda.range.invalid_start"))
+	}
+	end, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, dates[1], location)
+	if err != nil {
+		return time.Time{}, time.Time{}, "", errors.New(format.T("agenda.range.invalid_end"))
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, "", errors.New(format.T("agenda.range.start_after_end"))
+	}
+	// the end date is inclusive
+	end = end.AddDate(0, 0, 1)
+	if daysBetween(start, end) > maxAgendaDays {
+		return time.Time{}, time.Time{}, "", errors.New(format.N("agenda.range.too_long", maxAgendaDays))
+	}
+	title := format.T("agenda.range.dates", format.Date(start), format.Date(end.AddDate(0, 0, -1)))
+	return start, end, title, nil
+}
+
+// listEventsInRange collects every single event of the primary calendar between start and end
+func (p *Plugin) listEventsInRange(cal *CalendarService, start, end time.Time) ([]*calendar.Event, error) {
+	request := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID).ShowDeleted(false).
+		SingleEvents(true).TimeMin(start.Format(time.RFC3339)).TimeMax(end.Format(time.RFC3339)).OrderBy("startTime")
+
+	var allEvents []*calendar.Event
+	var pageToken string
+	for ok := true; ok; ok = pageToken != "" {
+		request.PageToken(pageToken)
+		events, err := request.Do()
+		if err != nil {
+			return nil, err
+		}
+		allEvents = append(allEvents, events.Items...)
+		pageToken = events.NextPageToken
+	}
+	return allEvents, nil
+}
+
+// groupEventsByDay lists the events of each day between start and end, events covering several days
+// are listed on every one of those days
+func groupEventsByDay(events []*calendar.Event, location *time.Location, start, end time.Time) []agendaDay {
+	var days []agendaDay
+	for day := dateOf(start, location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		agenda := agendaDay{date: day}
+		for _, event := range events {
+			if newEventTime(event, location).DayNumber(day) > 0 {
+				agenda.events = append(agenda.events, event)
+			}
+		}
+		if len(agenda.events) > 0 {
+			days = append(days, agenda)
+		}
+	}
+	return days
+}
+
+// renderAgenda renders the days as markdown, split in as many posts as needed to fit in the post size limit
+func (p *Plugin) renderAgenda(title string, days []agendaDay, format timeFormat, compact bool) []string {
+	var posts []string
+	current := format.T("agenda.title", title)
+
+	tableHeader := format.T("agenda.table_header") + "\n|:-----|:------|:------|:-------|\n"
+	for _, day := range days {
+		date := format.Date(day.date)
+		dayHeader := fmt.Sprintf("\n##### %s\n", date)
+		if compact {
+			dayHeader = fmt.Sprintf("\n**%s**\n", date)
+		}
+		header := dayHeader
+		if !compact {
+			header += tableHeader
+		}
+
+		current += header
+		for _, event := range day.events {
+			var row string
+			if compact {
+				row = p.printAgendaLine(event, format, day.date)
+			} else {
+				row = p.printAgendaRow(event, format, day.date)
+			}
+
+			if utf8.RuneCountInString(current)+utf8.RuneCountInString(row) > agendaPostLimit {
+				posts = append(posts, current)
+				current = strings.Replace(header, date, format.T("agenda.continued", date), 1)
+			}
+			current += row
+		}
+	}
+	return append(posts, current)
+}
+
+func (p *Plugin) printAgendaRow(event *calendar.Event, format timeFormat, day time.Time) string {
+	return fmt.Sprintf("| %s | [%s](%s) | %s | %s |\n",
+		printAgendaTime(event, format, day),
+		escapeTableCell(event.Summary), event.HtmlLink,
+		escapeTableCell(printAgendaWhere(event)),
+		p.printAgendaResponse(event, format))
+}
+
+func (p *Plugin) printAgendaLine(event *calendar.Event, format timeFormat, day time.Time) string {
+	line := fmt.Sprintf("- %s [%s](%s)", printAgendaTime(event, format, day), event.Summary, event.HtmlLink)
+	if where := printAgendaWhere(event); where != "" {
+		line += " · " + where
+	}
+	return line + "\n"
+}
+
+// printAgendaTime prints the time of the event on the given day of the agenda
+func printAgendaTime(event *calendar.Event, format timeFormat, day time.Time) string {
+	et := newEventTime(event, format.location)
+	start := format.Time(et.Start)
+	end := format.Time(et.End)
+	if !et.IsMultiDay() {
+		if et.AllDay {
+			return format.T("agenda.time.all_day")
+		}
+		return fmt.Sprintf("%s - %s", start, end)
+	}
+
+	n := et.DayNumber(day)
+	switch {
+	case et.AllDay:
+		return format.T("agenda.time.all_day_of", n, et.Days())
+	case n == 1:
+		return format.T("agenda.time.from", start, n, et.Days())
+	case n == et.Days():
+		return format.T("agenda.time.until", end, n, et.Days())
+	default:
+		return format.T("agenda.time.day_of", n, et.Days())
+	}
+}
+
+func printAgendaWhere(event *calendar.Event) string {
+	where := strings.ReplaceAll(event.Location, "\n", " ")
+	if event.HangoutLink != "" {
+		if where != "" {
+			where += " / "
+		}
+		where += fmt.Sprintf("[Meet](%s)", event.HangoutLink)
+	}
+	return where
+}
+
+func (p *Plugin) printAgendaResponse(event *calendar.Event, format timeFormat) string {
+	self := p.retrieveMyselfForEvent(event)
+	if self == nil {
+		return format.T("response.yes")
+	}
+	switch self.ResponseStatus {
+	case constant.EV_STATUS_NEED_ACTION:
+		url := fmt.Sprintf("%s/plugins/%s/handleresponse?evtid=%s&",
+			p.getConfiguration().SiteUrl, manifest.ID, event.Id)
+		return format.T("agenda.response.links", url+"response=accepted", url+"response=declined", url+"response=tentative")
+	case constant.EV_STATUS_DECLINED:
+		return format.T("response.no")
+	case constant.EV_STATUS_TENTATIVE:
+		return format.T("response.maybe")
+	default:
+		return format.T("response.yes")
+	}
+}
+
+func escapeTableCell(text string) string {
+	text = strings.ReplaceAll(text, "\n", " ")
+	return strings.ReplaceAll(text, "|", "\\|")
+}
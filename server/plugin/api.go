@@ -3,15 +3,19 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/helper"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/calendar/v3"
@@ -28,6 +32,13 @@ func (p *Plugin) registerRouter() {
 	router.HandleFunc("/watch", p.watchCalendar)
 	router.HandleFunc("/settings", p.setSettings)
 	router.HandleFunc("/disconnect", p.disconnectCalendar)
+	router.HandleFunc("/edit", p.editEvent)
+	router.HandleFunc("/autocomplete/events", p.autocompleteEvents)
+	router.HandleFunc("/search/more", p.searchMore)
+	router.HandleFunc("/responses/nudge", p.nudgePending)
+	router.HandleFunc("/event/action", p.handleEventCardAction)
+	router.HandleFunc("/reminder/action", p.handleReminderAction)
+	router.HandleFunc("/metrics", p.serveMetrics)
 	p.router = router
 }
 
@@ -107,7 +118,9 @@ func (p *Plugin) completeCalendar(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := calendarConfig.Exchange(context.Background(), code)
+	ctx, cancel := context.WithTimeout(p.ctx, googleRequestTimeout)
+	defer cancel()
+	token, err := calendarConfig.Exchange(ctx, code)
 	if err != nil {
 		http.Error(w, "Error setting up Config Exchange", http.StatusBadRequest)
 		return
@@ -140,12 +153,22 @@ func (p *Plugin) completeCalendar(w http.ResponseWriter, r *http.Request) {
 		EncryptSecret: secret,
 	})
 	if err != nil {
-		http.Error(w, "failed to set token", http.StatusInternalServerError)
+		p.API.LogError("Error setting token", "err", err.Error())
+		http.Error(w, "failed to set token", apperr.HTTPStatus(err))
 		return
 	}
+	// the new token replaces the cached one and the one Google refused
+	p.calendarCache.invalidate(user.UserID)
+	if err := p.services.lookupService.Delete(models.LookupsRequest{
+		UserID: user.UserID,
+		Key:    constant.TOKEN_INVALID_KEY,
+	}); err != nil {
+		p.API.LogError("Error deleting lookup", "err", err.Error())
+	}
 
-	if err := p.CalendarSyncV2(*user); err != nil {
-		http.Error(w, "failed sync fresh calender", http.StatusInternalServerError)
+	if err := p.CalendarSyncV2(r.Context(), *user); err != nil {
+		p.API.LogError("Error syncing calendar", "err", err.Error())
+		http.Error(w, "failed sync fresh calender", apperr.HTTPStatus(err))
 		return
 	}
 	if isUpdate {
@@ -156,14 +179,12 @@ func (p *Plugin) completeCalendar(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err = p.setupCalendarWatchV2(*user); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		http.Error(w, err.Error(), apperr.HTTPStatus(err))
 		return
 	}
 
 	// Post intro post
-	message := "#### Welcome to the Mattermost Google Calendar Plugin!\n" +
-		"You've successfully connected your Mattermost account to your Google Calendar.\n" +
-		"Please type **/calendar help** to understand how to user this plugin. "
+	message := p.getLocalizer(userID).T("connect.welcome")
 
 	if err := p.CreateBotDMPost(userID, message); err != nil {
 		p.API.LogError("Failed to post intro message", "err", err.Error())
@@ -204,9 +225,9 @@ func (p *Plugin) completeCalendar(w http.ResponseWriter, r *http.Request) {
 
 // 	cal, err := p.getCalendarService(userID)
 // 	if err != nil {
-// 		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+// 		if errors.Is(err, apperr.ErrNotConnected) {
 // 			// tell user to connect first
-// 			if appErr := p.CreateBotDMPost(userID, constant.ERR_CONNECT_FIRST); appErr != nil {
+// 			if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, err)); appErr != nil {
 // 				p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 // 				return
 // 			}
@@ -285,15 +306,15 @@ func (p *Plugin) deleteEvent(w http.ResponseWriter, r *http.Request) {
 	eventID := r.URL.Query().Get("evtid")
 	cal, err := p.getCalendarService(userID)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+		if errors.Is(err, apperr.ErrNotConnected) {
 			// tell user to connect first
-			if appErr := p.CreateBotDMPost(userID, constant.ERR_CONNECT_FIRST); appErr != nil {
+			if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, err)); appErr != nil {
 				p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 				return
 			}
 		}
 
-		if appErr := p.CreateBotDMPost(userID, fmt.Sprintf("Unable to delete event. Error: %s", err)); appErr != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.delete.failed", err)); appErr != nil {
 			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 		}
 		return
@@ -302,13 +323,13 @@ func (p *Plugin) deleteEvent(w http.ResponseWriter, r *http.Request) {
 	calendarID := p.getPrimaryCalendarID(userID)
 	eventToBeDeleted, err := cal.service.Events.Get(calendarID, eventID).Do()
 	if err != nil {
-		if appErr := p.CreateBotDMPost(userID, fmt.Sprintf("Unable to delete event. Error: %s", err)); appErr != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.delete.failed", err)); appErr != nil {
 			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 		}
 		return
 	}
-	if !eventToBeDeleted.Organizer.Self {
-		if appErr := p.CreateBotDMPost(userID, "You can only delete events that you have created."); appErr != nil {
+	if eventToBeDeleted.Organizer == nil || !eventToBeDeleted.Organizer.Self {
+		if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, apperr.NotOrganizer("delete"))); appErr != nil {
 			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 		}
 		return
@@ -316,13 +337,14 @@ func (p *Plugin) deleteEvent(w http.ResponseWriter, r *http.Request) {
 
 	err = cal.service.Events.Delete(calendarID, eventID).Do()
 	if err != nil {
-		if appErr := p.CreateBotDMPost(userID, fmt.Sprintf("Unable to delete event. Error: %s", err.Error())); appErr != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.delete.failed", err)); appErr != nil {
 			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 		}
 		return
 	}
 
-	if appErr := p.CreateBotDMPost(userID, fmt.Sprintf("Success! Event _%s_ has been deleted.", eventToBeDeleted.Summary)); appErr != nil {
+	eventToBeDeleted.Status = constant.EV_STATUS_CANCELLED
+	if appErr := p.CreateBotDMEventPost(userID, eventToBeDeleted, p.getLocalizer(userID).T("event.delete.success", eventToBeDeleted.Summary)); appErr != nil {
 		p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -346,22 +368,22 @@ func (p *Plugin) handleEventResponse(w http.ResponseWriter, r *http.Request) {
 	eventID := r.URL.Query().Get("evtid")
 	cal, err := p.getCalendarService(userID)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+		if errors.Is(err, apperr.ErrNotConnected) {
 			// tell user to connect first
-			if appErr := p.CreateBotDMPost(userID, constant.ERR_CONNECT_FIRST); appErr != nil {
+			if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, err)); appErr != nil {
 				p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 				return
 			}
 		}
 
-		p.CreateBotDMPost(userID, fmt.Sprintf("Unable to respond to event. Error: %s", err))
+		p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.respond.failed", err))
 		return
 	}
 
 	calendarID := p.getPrimaryCalendarID(userID)
 	eventToBeUpdated, err := cal.service.Events.Get(calendarID, eventID).Do()
 	if err != nil {
-		p.CreateBotDMPost(userID, fmt.Sprintf("Error! Failed to update the response of _%s_ event.", eventToBeUpdated.Summary))
+		p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.respond.failed", err))
 		return
 	}
 
@@ -373,9 +395,9 @@ func (p *Plugin) handleEventResponse(w http.ResponseWriter, r *http.Request) {
 
 	event, err := cal.service.Events.Update(calendarID, eventID, eventToBeUpdated).Do()
 	if err != nil {
-		p.CreateBotDMPost(userID, fmt.Sprintf("Error! Failed to update the response of _%s_ event.", event.Summary))
+		p.CreateBotDMEventPost(userID, eventToBeUpdated, p.getLocalizer(userID).T("event.respond.update_failed", eventToBeUpdated.Summary))
 	} else {
-		p.CreateBotDMPost(userID, fmt.Sprintf("Success! Event _%s_ response has been updated.", event.Summary))
+		p.CreateBotDMEventPost(userID, event, p.getLocalizer(userID).T("event.respond.success", event.Summary))
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -396,6 +418,10 @@ func (p *Plugin) watchCalendar(w http.ResponseWriter, r *http.Request) {
 		p.API.LogError("Error getting watch token", "err", err.Error())
 		return
 	}
+	// only the notifications of the current watch channel of the user are counted
+	if watchToken.Value == channelID {
+		p.metrics.webhookHits.Inc(webhookState(state))
+	}
 
 	channelByte, err := p.services.lookupService.Get(models.LookupsRequest{
 		UserID: userID,
@@ -425,7 +451,7 @@ func (p *Plugin) watchCalendar(w http.ResponseWriter, r *http.Request) {
 	}
 	if state == "sync" {
 		p.API.LogInfo("watchCalendar State is => Sync")
-		if appErr := p.CreateBotDMPost(userID, "Google Calendar notification have syncronized with your Mattermost!"); appErr != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("connect.synced")); appErr != nil {
 			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 			return
 		}
@@ -435,9 +461,9 @@ func (p *Plugin) watchCalendar(w http.ResponseWriter, r *http.Request) {
 	if watchToken.Value != channelID || state != "exists" {
 		cal, err := p.getCalendarService(userID)
 		if err != nil {
-			if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+			if errors.Is(err, apperr.ErrNotConnected) {
 				// tell user to connect first
-				if appErr := p.CreateBotDMPost(userID, constant.ERR_CONNECT_FIRST); appErr != nil {
+				if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, err)); appErr != nil {
 					p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 					return
 				}
@@ -461,11 +487,103 @@ func (p *Plugin) watchCalendar(w http.ResponseWriter, r *http.Request) {
 
 	// success case
 	p.API.LogInfo("watchCalendar State is => Exists")
-	if err := p.CalendarSync(userID); err != nil {
+	if err := p.CalendarSync(r.Context(), userID); err != nil {
 		p.API.LogError("Error syncing calendar", "err", err.Error())
 	}
 }
 
+// validateSettings checks the settings submitted in the dialog, the errors are apperr.ValidationError
+// with a message in the language of the user
+func (p *Plugin) validateSettings(userID string, setSettingsReq SetSettingsDialog, localizer *i18n.Localizer) (models.UpdateUser, error) {
+	timeNoti, err := strconv.Atoi(setSettingsReq.TimeNotiBeforeEvent)
+	if err != nil {
+		return models.UpdateUser{}, apperr.Validation("TimeNotiBeforeEvent", localizer.T("settings.invalid.time_before"))
+	}
+
+	// ! must between 0 - 40320
+	if timeNoti < 0 || timeNoti > 40320 {
+		return models.UpdateUser{}, apperr.Validation("TimeNotiBeforeEvent", localizer.T("settings.invalid.time_before_range", 0, 40320))
+	}
+
+	allDayReminderTime := strings.TrimSpace(setSettingsReq.AllDayReminderTime)
+	if _, err := time.Parse(constant.REMINDER_TIME_FORMAT, allDayReminderTime); err != nil {
+		return models.UpdateUser{}, apperr.Validation("AllDayReminderTime", localizer.T("settings.invalid.reminder_time"))
+	}
+
+	backupContact := strings.TrimPrefix(strings.TrimSpace(setSettingsReq.OOOBackupContact), "@")
+	if backupContact != "" {
+		if _, appErr := p.API.GetUserByUsername(backupContact); appErr != nil {
+			return models.UpdateUser{}, apperr.Validation("OOOBackupContact", localizer.T("settings.invalid.backup_contact", backupContact))
+		}
+	}
+
+	syncNotificationLayout := setSettingsReq.SyncNotificationLayout
+	if syncNotificationLayout != constant.SYNC_LAYOUT_THREADS {
+		syncNotificationLayout = constant.SYNC_LAYOUT_COMBINED
+	}
+
+	// quiet hours are both set or both empty
+	quietHoursStart := strings.TrimSpace(setSettingsReq.QuietHoursStart)
+	quietHoursEnd := strings.TrimSpace(setSettingsReq.QuietHoursEnd)
+	if quietHoursStart != "" || quietHoursEnd != "" {
+		for _, quietHours := range []struct {
+			field string
+			value string
+		}{
+			{"QuietHoursStart", quietHoursStart},
+			{"QuietHoursEnd", quietHoursEnd},
+		} {
+			if _, err := time.Parse(constant.REMINDER_TIME_FORMAT, quietHours.value); err != nil {
+				return models.UpdateUser{}, apperr.Validation(quietHours.field, localizer.T("settings.invalid.quiet_hours", quietHours.field))
+			}
+		}
+		if quietHoursStart == quietHoursEnd {
+			return models.UpdateUser{}, apperr.Validation("QuietHoursEnd", localizer.T("settings.invalid.quiet_hours_same"))
+		}
+	}
+
+	// notifications go to the bot DM channel, ephemeral posts or a private channel of the user
+	deliveries := []struct {
+		field    string
+		delivery *string
+	}{
+		{"InviteDelivery", &setSettingsReq.InviteDelivery},
+		{"UpdateDelivery", &setSettingsReq.UpdateDelivery},
+		{"ReminderDelivery", &setSettingsReq.ReminderDelivery},
+		{"DigestDelivery", &setSettingsReq.DigestDelivery},
+	}
+	for _, delivery := range deliveries {
+		switch *delivery.delivery {
+		case "":
+			*delivery.delivery = constant.DELIVERY_DM
+		case constant.DELIVERY_DM, constant.DELIVERY_EPHEMERAL:
+		default:
+			if _, err := p.validateDeliveryChannel(userID, delivery.field, *delivery.delivery, localizer); err != nil {
+				return models.UpdateUser{}, err
+			}
+		}
+	}
+
+	return models.UpdateUser{
+		Setting: models.UserSettings{
+			TimeNotiBeforeEvent:    timeNoti,
+			AllDayReminderTime:     allDayReminderTime,
+			SyncNotificationLayout: syncNotificationLayout,
+			NotifyOnDecline:        setSettingsReq.NotifyOnDecline,
+			OOOAutoReply:           setSettingsReq.OOOAutoReply,
+			OOOBackupContact:       backupContact,
+			QuietHoursStart:        quietHoursStart,
+			QuietHoursEnd:          quietHoursEnd,
+			DigestMode:             digestMode(models.UserSettings{DigestMode: setSettingsReq.DigestMode}),
+			InviteDelivery:         setSettingsReq.InviteDelivery,
+			UpdateDelivery:         setSettingsReq.UpdateDelivery,
+			ReminderDelivery:       setSettingsReq.ReminderDelivery,
+			DigestDelivery:         setSettingsReq.DigestDelivery,
+		},
+		AllowNotify: HandleAllowNotiBooltoa(setSettingsReq.AllowNotify),
+	}, nil
+}
+
 func (p *Plugin) setSettings(w http.ResponseWriter, r *http.Request) {
 	var req model.SubmitDialogRequest
 	if err := Decode(r.Body, &req); err != nil {
@@ -484,37 +602,25 @@ func (p *Plugin) setSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userID := req.UserId
-	// validate
-	timeNoti, err := strconv.Atoi(setSettingsReq.TimeNotiBeforeEvent)
-	if err != nil {
-		if err := p.CreateBotDMPost(userID, "`TimeNotiBeforeEvent is not a number`"); err != nil {
-			p.API.LogError("Error creating bot post", "err", err.Error())
-			return
-		}
+	userID, ok := getDialogUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
 		return
 	}
-
-	// ! must between 0 - 40320
-	if timeNoti < 0 || timeNoti > 40320 {
-		if err := p.CreateBotDMPost(userID, "`TimeNotiBeforeEvent is not in range 0 - 40320`"); err != nil {
+	updatedUser, err := p.validateSettings(userID, setSettingsReq, p.getLocalizer(userID))
+	if err != nil {
+		if err := p.CreateBotDMPost(userID, p.userMessage(userID, err)); err != nil {
 			p.API.LogError("Error creating bot post", "err", err.Error())
-			return
 		}
 		return
 	}
-
-	updatedUser := models.UpdateUser{
-		Setting: models.UserSettings{
-			TimeNotiBeforeEvent: timeNoti,
-		},
-		AllowNotify: HandleAllowNotiBooltoa(setSettingsReq.AllowNotify),
-	}
 	if err := p.services.userService.UpdateUser(userID, updatedUser); err != nil {
 		p.API.LogError("Error updating user", "err", err.Error())
 		return
 	}
-	if err := p.CreateBotDMPost(userID, "Successfully update settings"); err != nil {
+	// the cached calendar service holds the previous settings
+	p.calendarCache.invalidate(userID)
+	if err := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("settings.updated")); err != nil {
 		p.API.LogError("Error creating bot post", "err", err.Error())
 		return
 	}
@@ -526,24 +632,336 @@ func (p *Plugin) disconnectCalendar(w http.ResponseWriter, r *http.Request) {
 		p.API.LogError("Parser error", "err", err.Error())
 		return
 	}
-	userID := req.UserId
+	userID, ok := getDialogUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
 	if err := p.stopWatch(userID); err != nil {
 		http.Error(w, "failed to stop watch", http.StatusInternalServerError)
 		return
 	}
 
 	// delete all user data
+	p.calendarCache.invalidate(userID)
+	if err := p.removeTeamDigestSubscriptions(userID); err != nil {
+		p.API.LogError("Error removing team digest subscriptions", "err", err.Error(), "userID", userID)
+	}
 	if err := p.services.userService.DeleteUserData(userID); err != nil {
 		p.API.LogError("Error deleting user data when disconnect", "err", err.Error())
-		if err := p.CreateBotDMPost(userID, "Error disconnecting calendar"); err != nil {
+		if err := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("disconnect.failed")); err != nil {
 			p.API.LogError("Error creating bot post", "err", err.Error())
 			return
 		}
 		return
 	}
 
-	if err := p.CreateBotDMPost(userID, "Disconnected calendar"); err != nil {
+	if err := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("disconnect.success")); err != nil {
 		p.API.LogError("Error creating bot post", "err", err.Error())
 		return
 	}
 }
+
+// autocompleteEvents lists the upcoming events organized by the user for the dynamic autocomplete
+func (p *Plugin) autocompleteEvents(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get(constant.MATTERMOST_USER_KEY)
+	items := []model.AutocompleteListItem{}
+	defer func() {
+		if err := Encode(w, items); err != nil {
+			p.API.LogError("Error encoding autocomplete items", "err", err.Error())
+		}
+	}()
+
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		p.API.LogError("Error getting calendar service", "err", err.Error())
+		return
+	}
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		return
+	}
+
+	events, err := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID).ShowDeleted(false).
+		SingleEvents(true).TimeMin(time.Now().Format(time.RFC3339)).MaxResults(50).OrderBy("startTime").Do()
+	if err != nil {
+		p.API.LogError("Error getting events", "err", err.Error())
+		return
+	}
+
+	for _, event := range events.Items {
+		if event.Organizer == nil || !event.Organizer.Self {
+			continue
+		}
+		items = append(items, model.AutocompleteListItem{
+			Item:     event.Id,
+			Hint:     formatEventDateTimeInput(event.Start, location, false),
+			HelpText: event.Summary,
+		})
+	}
+}
+
+func (p *Plugin) editEvent(w http.ResponseWriter, r *http.Request) {
+	var req model.SubmitDialogRequest
+	if err := Decode(r.Body, &req); err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+	submissionBytes, err := json.Marshal(req.Submission)
+	if err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+
+	var editReq EditEventDialog
+	if err := json.Unmarshal(submissionBytes, &editReq); err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+
+	userID, ok := getDialogUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	eventID := req.State
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, err)); appErr != nil {
+				p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+			}
+			return
+		}
+
+		p.API.LogError("Error getting calendar service", "err", err.Error())
+		return
+	}
+
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		p.API.LogError("Error getting primary calendar location", "err", err.Error())
+		return
+	}
+
+	oldEvent, err := cal.service.Events.Get(constant.PRIMARY_CALENDAR_ID, eventID).Do()
+	if err != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.failed", err)); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+	if oldEvent.Organizer == nil || !oldEvent.Organizer.Self {
+		if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, apperr.NotOrganizer("edit"))); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+
+	// validate
+	start, startTime, err := parseEventDateTimeInput(editReq.StartDateTime, location, false)
+	if err != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.invalid_start", editReq.StartDateTime)); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+	end, endTime, err := parseEventDateTimeInput(editReq.EndDateTime, location, true)
+	if err != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.invalid_end", editReq.EndDateTime)); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+	if (start.Date == "") != (end.Date == "") {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.mixed_all_day")); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+	if !startTime.Before(endTime) {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.start_after_end")); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+
+	// keep the existing attendees, so their responses are not reset
+	existingAttendees := make(map[string]*calendar.EventAttendee)
+	attendees := []*calendar.EventAttendee{}
+	for _, attendee := range oldEvent.Attendees {
+		if attendee.Self {
+			attendees = append(attendees, attendee)
+			continue
+		}
+		existingAttendees[strings.ToLower(attendee.Email)] = attendee
+	}
+	for _, member := range strings.Fields(editReq.Attendees) {
+		email, err := p.services.attendeeService.EmailOf(member)
+		if err != nil {
+			if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.failed", err)); appErr != nil {
+				p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+			}
+			return
+		}
+		if attendee, ok := existingAttendees[strings.ToLower(email)]; ok {
+			attendees = append(attendees, attendee)
+			delete(existingAttendees, strings.ToLower(email))
+			continue
+		}
+		attendees = append(attendees, &calendar.EventAttendee{
+			Email: email,
+		})
+	}
+
+	sendUpdates := editReq.SendUpdates
+	if sendUpdates == "" {
+		sendUpdates = constant.SEND_UPDATES_ALL
+	}
+
+	patch := &calendar.Event{
+		Summary:         editReq.Title,
+		Start:           start,
+		End:             end,
+		Location:        editReq.Location,
+		Description:     editReq.Description,
+		Attendees:       attendees,
+		ForceSendFields: []string{"Location", "Description", "Attendees"},
+	}
+	updatedEvent, err := cal.service.Events.Patch(constant.PRIMARY_CALENDAR_ID, eventID, patch).SendUpdates(sendUpdates).Do()
+	if err != nil {
+		if appErr := p.CreateBotDMPost(userID, p.getLocalizer(userID).T("event.edit.update_failed", err)); appErr != nil {
+			p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+		}
+		return
+	}
+
+	format := p.getTimeFormat(userID)
+	textToPost, _ := p.printEventUpdate(oldEvent, updatedEvent, format, true)
+	card := p.newEventCard(userID, updatedEvent, format, time.Time{})
+	if appErr := p.CreateBotDMEventPostWithCard(userID, updatedEvent, textToPost, card); appErr != nil {
+		p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+	}
+}
+
+// searchMore posts the next page of a search when the "More" button is clicked
+func (p *Plugin) searchMore(w http.ResponseWriter, r *http.Request) {
+	var req model.PostActionIntegrationRequest
+	if err := Decode(r.Body, &req); err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+
+	userID, ok := getActionUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	response := &model.PostActionIntegrationResponse{}
+	defer func() {
+		if err := Encode(w, response); err != nil {
+			p.API.LogError("Error encoding action response", "err", err.Error())
+		}
+	}()
+
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			response.EphemeralText = p.userMessage(userID, err)
+			return
+		}
+
+		p.API.LogError("Error getting calendar service", "err", err.Error())
+		return
+	}
+
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		p.API.LogError("Error getting primary calendar location", "err", err.Error())
+		return
+	}
+
+	text, _ := req.Context["text"].(string)
+	attendee, _ := req.Context["attendee"].(string)
+	fromValue, _ := req.Context["from"].(string)
+	toValue, _ := req.Context["to"].(string)
+	page, _ := req.Context["page"].(float64)
+	from, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, fromValue, location)
+	if err != nil {
+		response.EphemeralText = p.getLocalizer(userID).T("search.invalid")
+		return
+	}
+	to, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, toValue, location)
+	if err != nil {
+		response.EphemeralText = p.getLocalizer(userID).T("search.invalid")
+		return
+	}
+
+	search := eventSearch{
+		Text:     text,
+		From:     from,
+		To:       to,
+		Attendee: attendee,
+	}
+	results, err := p.searchEvents(userID, cal, search, location)
+	if err != nil {
+		p.API.LogError("Error searching events", "err", err.Error())
+		response.EphemeralText = p.getLocalizer(userID).T("events.fetch_failed")
+		return
+	}
+
+	// the button is replaced by the next page
+	if post, appErr := p.API.GetPost(req.PostId); appErr == nil {
+		post.DelProp("attachments")
+		response.Update = post
+	}
+
+	if appErr := p.postSearchResults(userID, search, results, int(page)); appErr != nil {
+		p.API.LogError("Error creating bot post", "apErr", appErr.Error())
+	}
+}
+
+func (p *Plugin) nudgePending(w http.ResponseWriter, r *http.Request) {
+	var req model.PostActionIntegrationRequest
+	if err := Decode(r.Body, &req); err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+
+	userID, ok := getActionUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	response := &model.PostActionIntegrationResponse{}
+	defer func() {
+		if err := Encode(w, response); err != nil {
+			p.API.LogError("Error encoding action response", "err", err.Error())
+		}
+	}()
+
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			response.EphemeralText = p.userMessage(userID, err)
+			return
+		}
+
+		p.API.LogError("Error getting calendar service", "err", err.Error())
+		return
+	}
+
+	eventID, _ := req.Context["evtid"].(string)
+	event, err := cal.service.Events.Get(constant.PRIMARY_CALENDAR_ID, eventID).Do()
+	if err != nil {
+		response.EphemeralText = p.getLocalizer(userID).T("event.not_found", eventID)
+		return
+	}
+	if event.Organizer == nil || !event.Organizer.Self {
+		response.EphemeralText = p.userMessage(userID, apperr.NotOrganizer("nudge"))
+		return
+	}
+
+	response.EphemeralText = p.nudgeAttendees(userID, event)
+}
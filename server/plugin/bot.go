@@ -1,9 +1,16 @@
 package plugin
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"google.golang.org/api/calendar/v3"
 )
 
 // CreateBotDMPost used to post as google calendar bot to the user directly
@@ -26,3 +33,179 @@ func (p *Plugin) CreateBotDMPost(userID, message string) *model.AppError {
 	}
 	return nil
 }
+
+// CreateBotDMPostWithAttachments used to post as google calendar bot to the user directly, with message attachments
+func (p *Plugin) CreateBotDMPostWithAttachments(userID, message string, attachments []*model.SlackAttachment) *model.AppError {
+	return p.CreateBotNotificationPost(userID, constant.DELIVERY_DM, message, attachments)
+}
+
+// CreateBotNotificationPost used to post a notification as google calendar bot, with message
+// attachments, where the user gets it: the bot DM channel, one of their private channels or an
+// ephemeral post
+func (p *Plugin) CreateBotNotificationPost(userID, delivery, message string, attachments []*model.SlackAttachment) *model.AppError {
+	channelID, err := p.getDeliveryChannel(userID, delivery)
+	if err != nil {
+		return err
+	}
+
+	post := &model.Post{
+		UserId:    p.botID,
+		ChannelId: channelID,
+		Message:   fmt.Sprintf("--- \n %s", message),
+	}
+	if len(attachments) > 0 {
+		model.ParseSlackAttachment(post, attachments)
+	}
+
+	if delivery == constant.DELIVERY_EPHEMERAL {
+		p.API.SendEphemeralPost(userID, post)
+		return nil
+	}
+	if _, err := p.API.CreatePost(post); err != nil {
+		p.API.LogError("Couldn't create bot post", "user_id", userID)
+		return err
+	}
+	return nil
+}
+
+// CreateBotDMEventPost used to post about an event in the thread of that event. The first post about
+// an event becomes the root of the thread, later posts reply to it and the root is edited to show
+// the current state of the event
+func (p *Plugin) CreateBotDMEventPost(userID string, event *calendar.Event, message string) *model.AppError {
+	return p.CreateBotDMEventPostWithCard(userID, event, message, nil)
+}
+
+// CreateBotDMEventPostWithCard used to post about an event in the thread of that event, with the
+// card of the event under the message
+func (p *Plugin) CreateBotDMEventPostWithCard(userID string, event *calendar.Event, message string, card *model.SlackAttachment) *model.AppError {
+	return p.CreateBotNotificationEventPost(userID, constant.DELIVERY_DM, event, message, card)
+}
+
+// CreateBotNotificationEventPost used to post a notification about an event in the thread of that
+// event, where the user gets it. A thread moves to the channel of its latest post, ephemeral posts
+// have no thread
+func (p *Plugin) CreateBotNotificationEventPost(userID, delivery string, event *calendar.Event, message string, card *model.SlackAttachment) *model.AppError {
+	if delivery == constant.DELIVERY_EPHEMERAL {
+		var cards []*model.SlackAttachment
+		if card != nil {
+			cards = append(cards, card)
+		}
+		return p.CreateBotNotificationPost(userID, delivery, message, cards)
+	}
+
+	channelID, err := p.getDeliveryChannel(userID, delivery)
+	if err != nil {
+		return err
+	}
+
+	post := &model.Post{
+		UserId:    p.botID,
+		ChannelId: channelID,
+		Message:   fmt.Sprintf("--- \n %s", message),
+	}
+	if card != nil {
+		model.ParseSlackAttachment(post, []*model.SlackAttachment{card})
+	}
+
+	root := p.getEventThreadRoot(userID, event.Id)
+	if root != nil && root.ChannelId != channelID {
+		root = nil
+	}
+	if root != nil {
+		post.RootId = root.Id
+	}
+
+	createdPost, err := p.API.CreatePost(post)
+	if err != nil {
+		p.API.LogError("Couldn't create bot post", "user_id", userID)
+		return err
+	}
+
+	if root == nil {
+		if err := p.services.lookupService.Set(models.Lookups{
+			UserID: userID,
+			Key:    eventThreadKey(event.Id),
+			Value:  createdPost.Id,
+		}); err != nil {
+			p.API.LogError("Couldn't save event thread", "user_id", userID, "err", err.Error())
+		}
+		return nil
+	}
+
+	format := p.getTimeFormat(userID)
+	root.Message = fmt.Sprintf("--- \n %s", p.printEventThreadRoot(event, format.localizer))
+	root.DelProp("attachments")
+	if !p.isEventDeleted(event) {
+		model.ParseSlackAttachment(root, []*model.SlackAttachment{p.newEventCard(userID, event, format, time.Time{})})
+	}
+	if _, err := p.API.UpdatePost(root); err != nil {
+		p.API.LogError("Couldn't update event thread root", "user_id", userID)
+		return err
+	}
+
+	// nothing more will be posted about a cancelled event
+	if p.isEventDeleted(event) {
+		if err := p.services.lookupService.Delete(models.LookupsRequest{
+			UserID: userID,
+			Key:    eventThreadKey(event.Id),
+		}); err != nil {
+			p.API.LogError("Couldn't delete event thread", "user_id", userID, "err", err.Error())
+		}
+	}
+	return nil
+}
+
+// getEventThreadRoot returns the root post of the event thread, or nil when there is none yet
+func (p *Plugin) getEventThreadRoot(userID, eventID string) *model.Post {
+	rootID, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    eventThreadKey(eventID),
+	})
+	if err != nil || rootID == nil {
+		return nil
+	}
+
+	root, appErr := p.API.GetPost(rootID.Value)
+	if appErr != nil || root.DeleteAt != 0 {
+		return nil
+	}
+	return root
+}
+
+// printEventThreadRoot prints the root post of the thread of the event, its card shows the current
+// state of the event
+func (p *Plugin) printEventThreadRoot(event *calendar.Event, localizer *i18n.Localizer) string {
+	if p.isEventDeleted(event) {
+		return localizer.T("thread.cancelled", event.Summary, event.HtmlLink)
+	}
+	return localizer.T("thread.event")
+}
+
+// pruneEventThreads forgets the threads of the stored events that are over, nothing more is
+// posted about them
+func (p *Plugin) pruneEventThreads(userID string) error {
+	events, err := p.getStoredEvents(userID)
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var keys []string
+	for _, event := range events {
+		if newEventTime(event, location).End.Before(now) {
+			keys = append(keys, eventThreadKey(event.Id))
+		}
+	}
+	return p.services.lookupService.DeleteKeys(userID, keys)
+}
+
+func eventThreadKey(eventID string) string {
+	return constant.EVENT_THREAD_KEY_PREFIX + eventID
+}
@@ -3,14 +3,17 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"sort"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/templates"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/calendar/v3"
@@ -41,9 +44,32 @@ func (p *Plugin) CalendarConfig() *oauth2.Config {
 	}
 }
 
-// getCalendarService retrieve token stored in database and then generates a google calendar service
+// newCalendarService creates a calendar service for the user whose requests are counted in the
+// metrics, retried when rate limited and cancelled with ctx
+func (p *Plugin) newCalendarService(ctx context.Context, userID string, tokenSource oauth2.TokenSource) (*calendar.Service, error) {
+	client := &http.Client{
+		Transport: &googleTransport{
+			base: &instrumentedTransport{
+				base: &oauth2.Transport{
+					Source: &instrumentedTokenSource{base: tokenSource, metrics: p.metrics},
+					Base:   http.DefaultTransport,
+				},
+				metrics: p.metrics,
+			},
+			ctx:    ctx,
+			userID: userID,
+			plugin: p,
+		},
+	}
+	return calendar.NewService(ctx, option.WithHTTPClient(client))
+}
+
+// getCalendarService retrieve token stored in database and then generates a google calendar service.
+// The service is cached for a while, see calendarCache
 func (p *Plugin) getCalendarService(userID string) (*CalendarService, error) {
-	var token oauth2.Token
+	if cal := p.calendarCache.getService(userID, time.Now()); cal != nil {
+		return cal, nil
+	}
 
 	// get calendar token from database
 	secret := p.getConfiguration().EncryptionSecret
@@ -52,29 +78,19 @@ func (p *Plugin) getCalendarService(userID string) (*CalendarService, error) {
 		p.API.LogError("Error getting user", "err", err.Error())
 		return nil, err
 	}
-	tokenInByte := []byte(user.CalendarToken)
-	if err := json.Unmarshal(tokenInByte, &token); err != nil {
-		return nil, err
-	}
-
-	config := p.CalendarConfig()
-	ctx := context.Background()
-	tokenSource := config.TokenSource(ctx, &token)
-	srv, err := calendar.NewService(ctx, option.WithTokenSource(tokenSource))
-	if err != nil {
-		return nil, err
-	}
-
-	return &CalendarService{
-		service:      srv,
-		userSettings: user.Settings,
-		allowNotify:  user.AllowNotify,
-		email:        user.Email,
-	}, nil
+	return p.newUserCalendarService(user)
 }
 
 // getCalendarServiceV2 receive user instead of userID
 func (p *Plugin) getCalendarServiceV2(user models.UserDataDto) (*CalendarService, error) {
+	if cal := p.calendarCache.getService(user.UserID, time.Now()); cal != nil {
+		return cal, nil
+	}
+	return p.newUserCalendarService(user)
+}
+
+// newUserCalendarService creates the calendar service of the user and caches it
+func (p *Plugin) newUserCalendarService(user models.UserDataDto) (*CalendarService, error) {
 	var token oauth2.Token
 	tokenInByte := []byte(user.CalendarToken)
 	if err := json.Unmarshal(tokenInByte, &token); err != nil {
@@ -82,119 +98,107 @@ func (p *Plugin) getCalendarServiceV2(user models.UserDataDto) (*CalendarService
 	}
 
 	config := p.CalendarConfig()
-	ctx := context.Background()
-	tokenSource := config.TokenSource(ctx, &token)
-	srv, err := calendar.NewService(ctx, option.WithTokenSource(tokenSource))
+	srv, err := p.newCalendarService(p.ctx, user.UserID, config.TokenSource(p.ctx, &token))
 	if err != nil {
 		return nil, err
 	}
-	return &CalendarService{
+
+	cal := &CalendarService{
 		service:      srv,
 		userSettings: user.Settings,
 		allowNotify:  user.AllowNotify,
-	}, nil
+		email:        user.Email,
+	}
+	p.calendarCache.setService(user.UserID, cal, time.Now())
+	return cal, nil
 }
 
 func (p *Plugin) getPrimaryCalendarLocation(userID string) (*time.Location, error) {
-	cal, err := p.getCalendarService(userID)
-	if err != nil {
-		p.API.LogError("Error getting calendar service", "err", err.Error())
-		return nil, err
-	}
-	primaryCalendar, err := cal.service.Calendars.Get(constant.PRIMARY_CALENDAR_ID).Do()
+	metadata, err := p.getCalendarMetadata(userID)
 	if err != nil {
 		p.API.LogError("Error getting primary calendar", "err", err.Error())
 		return nil, err
 	}
-	timezone := primaryCalendar.TimeZone
-	location, err := time.LoadLocation(timezone)
-	if err != nil {
-		return nil, err
-	}
-
-	return location, nil
+	return metadata.location, nil
 }
 
 // CalendarSync either does a full sync or a incremental sync. Taken from googles sample code
 // To better understand whats going on here, you can read https://developers.google.com/calendar/v3/sync
-func (p *Plugin) CalendarSync(userID string) error {
+func (p *Plugin) CalendarSync(ctx context.Context, userID string) (err error) {
+	defer func() {
+		p.recordSyncResult(userID, err)
+	}()
+
 	cal, err := p.getCalendarService(userID)
 	if err != nil {
 		p.API.LogError("Error getting calendar service", "err", err.Error())
 		return err
 	}
+	return p.syncCalendar(ctx, userID, cal)
+}
 
-	request := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID)
-	isIncrementalSync := false
+func (p *Plugin) CalendarSyncV2(ctx context.Context, user models.UserDataDto) (err error) {
+	defer func() {
+		p.recordSyncResult(user.UserID, err)
+	}()
 
-	syncToken, err := p.services.lookupService.Get(models.LookupsRequest{
-		UserID: userID,
-		Key:    constant.SYNC_TOKEN_KEY,
-	})
-	if err != nil || syncToken == nil {
-		// Perform a Full Sync
-		oneMonthFromNow := time.Now().AddDate(0, 1, 0).Format(time.RFC3339)
-		request.TimeMin(time.Now().Format(time.RFC3339)).TimeMax(oneMonthFromNow).SingleEvents(true)
-	} else {
-		// Performing a Incremental Sync
-		request.SyncToken(syncToken.Value).ShowDeleted(true)
-		isIncrementalSync = true
+	cal, err := p.getCalendarServiceV2(user)
+	if err != nil {
+		p.API.LogError("Error getting calendar service", "err", err.Error())
+		return err
 	}
+	return p.syncCalendar(ctx, user.UserID, cal)
+}
 
-	var pageToken string
-	var events *calendar.Events
-	var allEvents []*calendar.Event
+// syncCalendar syncs the stored events of the user with their calendar. An expired sync token is
+// replaced by a full sync within the same sync
+func (p *Plugin) syncCalendar(ctx context.Context, userID string, cal *CalendarService) (err error) {
+	isIncrementalSync := false
+	start := time.Now()
+	defer func() {
+		p.metrics.observeSync(isIncrementalSync, start, err)
+	}()
 
-	// syncing events
-	for ok := true; ok; ok = pageToken != "" {
-		request.PageToken(pageToken)
-		events, err = request.Do()
-		if err != nil {
-			if err := p.services.lookupService.Delete(models.LookupsRequest{
-				UserID: userID,
-				Key:    constant.SYNC_TOKEN_KEY,
-			}); err != nil {
-				return err
-			}
-			if err := p.services.lookupService.Delete(models.LookupsRequest{
-				UserID: userID,
-				Key:    constant.EVENTS_KEY,
-			}); err != nil {
-				return err
-			}
-			if err := p.CalendarSync(userID); err != nil {
-				p.API.LogError("Error syncing calendar", "err", err.Error())
-				return err
-			}
+	oneMonthFromNow := time.Now().AddDate(0, 1, 0).Format(time.RFC3339)
+	var allEvents []*calendar.Event
+	var nextSyncToken string
+	for {
+		allEvents, nextSyncToken, isIncrementalSync, err = p.listSyncEvents(ctx, userID, cal, oneMonthFromNow)
+		// only an expired sync token is fixed by a full sync
+		if err == nil || !isIncrementalSync || !errors.Is(err, apperr.ErrSyncTokenExpired) {
+			break
 		}
-
-		if len(events.Items) != 0 {
-			allEvents = append(allEvents, events.Items...)
+		if err = p.dropStoredEvents(userID); err != nil {
+			return err
 		}
-		pageToken = events.NextPageToken
+	}
+	if err != nil {
+		return err
 	}
 
 	if err := p.services.lookupService.Set(models.Lookups{
 		UserID: userID,
 		Key:    constant.SYNC_TOKEN_KEY,
-		Value:  events.NextSyncToken,
+		Value:  nextSyncToken,
 	}); err != nil {
 		return err
 	}
 
+	// do incremental-sync
 	if isIncrementalSync {
-		// after incremental-sync
-		if err := p.updateEventsInDatabase(userID, cal.allowNotify, allEvents); err != nil {
+		if err := p.updateEventsInDatabase(userID, cal.allowNotify, cal.userSettings, allEvents); err != nil {
 			p.API.LogError("Error updating events in database", "err", err.Error())
 			return err
 		}
 		return nil
 	}
 
-	// after full-sync
-	sort.Slice(allEvents, func(i, j int) bool {
-		return allEvents[i].Start.DateTime < allEvents[j].Start.DateTime
-	})
+	// do full-sync
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		return err
+	}
+	sortEvents(allEvents, location)
 	allEventsJSON, err := json.Marshal(allEvents)
 	if err != nil {
 		return err
@@ -208,248 +212,115 @@ func (p *Plugin) CalendarSync(userID string) error {
 		return err
 	}
 
-	return nil
-}
-
-func (p *Plugin) CalendarSyncV2(user models.UserDataDto) error {
-	cal, err := p.getCalendarServiceV2(user)
-	if err != nil {
-		p.API.LogError("Error getting calendar service", "err", err.Error())
+	// the stored events cover up to the end of the full sync, incremental syncs keep them up to date
+	if err := p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.EVENTS_WINDOW_END_KEY,
+		Value:  oneMonthFromNow,
+	}); err != nil {
 		return err
 	}
 
-	request := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID)
-	isIncrementalSync := false
+	return nil
+}
 
+// listSyncEvents lists the events changed since the last sync, or the events until windowEnd when
+// there is no sync token. It returns the events, the next sync token and whether the sync is
+// incremental
+func (p *Plugin) listSyncEvents(ctx context.Context, userID string, cal *CalendarService, windowEnd string) ([]*calendar.Event, string, bool, error) {
+	request := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID).Context(ctx)
 	syncToken, err := p.services.lookupService.Get(models.LookupsRequest{
-		UserID: user.UserID,
+		UserID: userID,
 		Key:    constant.SYNC_TOKEN_KEY,
 	})
-	if err != nil || syncToken == nil {
+	isIncrementalSync := err == nil && syncToken != nil
+	if !isIncrementalSync {
 		// Perform a Full Sync
-		oneMonthFromNow := time.Now().AddDate(0, 1, 0).Format(time.RFC3339)
-		request.TimeMin(time.Now().Format(time.RFC3339)).TimeMax(oneMonthFromNow).SingleEvents(true)
+		request.TimeMin(time.Now().Format(time.RFC3339)).TimeMax(windowEnd).SingleEvents(true)
 	} else {
 		// Performing a Incremental Sync
 		request.SyncToken(syncToken.Value).ShowDeleted(true)
-		isIncrementalSync = true
 	}
 
 	var pageToken string
-	var events *calendar.Events
 	var allEvents []*calendar.Event
-
-	// collect all events
-	for ok := true; ok; ok = pageToken != "" {
+	for {
 		request.PageToken(pageToken)
-		events, err = request.Do()
+		events, err := request.Do()
 		if err != nil {
-			if err := p.services.lookupService.Delete(models.LookupsRequest{
-				UserID: user.UserID,
-				Key:    constant.SYNC_TOKEN_KEY,
-			}); err != nil {
-				return err
-			}
-			if err := p.services.lookupService.Delete(models.LookupsRequest{
-				UserID: user.UserID,
-				Key:    constant.EVENTS_KEY,
-			}); err != nil {
-				return err
-			}
-			if err := p.CalendarSyncV2(user); err != nil {
-				p.API.LogError("Error syncing calendar", "err", err.Error())
-				return err
-			}
-		}
-
-		if len(events.Items) != 0 {
-			allEvents = append(allEvents, events.Items...)
+			return nil, "", isIncrementalSync, apperr.FromGoogle(err)
 		}
+		allEvents = append(allEvents, events.Items...)
 		pageToken = events.NextPageToken
-	}
-
-	if err := p.services.lookupService.Set(models.Lookups{
-		UserID: user.UserID,
-		Key:    constant.SYNC_TOKEN_KEY,
-		Value:  events.NextSyncToken,
-	}); err != nil {
-		return err
-	}
-
-	// do incremental-sync
-	if isIncrementalSync {
-		if err := p.updateEventsInDatabase(user.UserID, cal.allowNotify, allEvents); err != nil {
-			p.API.LogError("Error updating events in database", "err", err.Error())
-			return err
+		if pageToken == "" {
+			return allEvents, events.NextSyncToken, isIncrementalSync, nil
 		}
-		return nil
-	}
-
-	// do full-sync
-	sort.Slice(allEvents, func(i, j int) bool {
-		return allEvents[i].Start.DateTime < allEvents[j].Start.DateTime
-	})
-	allEventsJSON, err := json.Marshal(allEvents)
-	if err != nil {
-		return err
 	}
-
-	if err := p.services.lookupService.Set(models.Lookups{
-		UserID: user.UserID,
-		Key:    constant.EVENTS_KEY,
-		Value:  string(allEventsJSON),
-	}); err != nil {
-		return err
-	}
-
-	return nil
 }
 
-// notify when user is invited to an event
-func (p *Plugin) updateEventsInDatabase(userID string, allowNotify string, latestEvents []*calendar.Event) error {
+// getStoredEvents returns the events kept up to date by the calendar sync
+func (p *Plugin) getStoredEvents(userID string) ([]*calendar.Event, error) {
 	eventsJSON, err := p.services.lookupService.Get(models.LookupsRequest{
 		UserID: userID,
 		Key:    constant.EVENTS_KEY,
 	})
 	if err != nil {
 		p.API.LogError("Error getting events from database", "err", err.Error())
-		return err
+		return nil, err
 	}
 
 	jsonArr := []interface{}{}
 	if err := json.Unmarshal([]byte(eventsJSON.Value), &jsonArr); err != nil {
 		p.API.LogError("Error unmarshalling events", "err", err.Error(), "events", eventsJSON.Value)
-		return err
+		return nil, err
 	}
 
 	ev, err := json.Marshal(jsonArr)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var events []*calendar.Event
 	if err := json.Unmarshal(ev, &events); err != nil {
 		p.API.LogError("Error getting events from database when unmarshal", "err", err.Error())
-		return err
+		return nil, err
 	}
 
-	var textToPost string
-	shouldPostMessage := true
-	hasChange := false
-	for _, changedEvent := range latestEvents {
-		for idx, oldEvent := range events {
-			// If this is a event we created, we don't want to make notifications
-			if changedEvent.Creator.Self {
-				shouldPostMessage = false
-			}
-			// If a current event in our database matches a event that has changed
-			if oldEvent.Id == changedEvent.Id {
-				textToPost = "**_Event Updated:_**\n"
-
-				// If the events title has changed, we want to show the difference from the old one
-				if oldEvent.Summary != changedEvent.Summary {
-					hasChange = true
-					textToPost += fmt.Sprintf("\n**~~[%v](%s)~~** ⟶ **[%v](%s)**\n", oldEvent.Summary, oldEvent.HtmlLink, changedEvent.Summary, changedEvent.HtmlLink)
-				} else {
-					textToPost += fmt.Sprintf("\n**[%v](%s)**\n", changedEvent.Summary, changedEvent.HtmlLink)
-				}
-
-				oldStartTime, _ := time.Parse(time.RFC3339, oldEvent.Start.DateTime)
-				oldEndTime, _ := time.Parse(time.RFC3339, oldEvent.End.DateTime)
-
-				changedStartTime, _ := time.Parse(time.RFC3339, changedEvent.Start.DateTime)
-				changedEndTime, _ := time.Parse(time.RFC3339, changedEvent.End.DateTime)
-
-				if !oldStartTime.Equal(changedStartTime) || !oldEndTime.Equal(changedEndTime) {
-					hasChange = true
-					textToPost += fmt.Sprintf("**When**: ~~%s @ %s to %s~~ ⟶ %s @ %s to %s\n", oldStartTime.Format(constant.DATE_FORMAT), oldStartTime.Format(constant.TIME_FORMAT),
-						oldEndTime.Format(constant.TIME_FORMAT), changedStartTime.Format(constant.DATE_FORMAT), changedStartTime.Format(constant.TIME_FORMAT), changedEndTime.Format(constant.TIME_FORMAT))
-				} else {
-					textToPost += fmt.Sprintf("**When**: %s @ %s to %s\n",
-						changedStartTime.Format(constant.DATE_FORMAT), changedStartTime.Format(constant.TIME_FORMAT), changedEndTime.Format(constant.TIME_FORMAT))
-				}
-
-				if oldEvent.Location != changedEvent.Location {
-					hasChange = true
-					textToPost += fmt.Sprintf("**Where**: ~~%s~~ ⟶ %s\n", oldEvent.Location, changedEvent.Location)
-				} else if changedEvent.Location != "" {
-					textToPost += fmt.Sprintf("**Where**: %s\n", changedEvent.Location)
-				}
-
-				if len(oldEvent.Attendees) != len(changedEvent.Attendees) {
-					hasChange = true
-					textToPost += fmt.Sprintf("**Guests**: ~~%+v (Organizer) & %v more~~ ⟶ %+v (Organizer) & %v more\n",
-						oldEvent.Organizer.Email, len(oldEvent.Attendees)-1, changedEvent.Organizer.Email, len(changedEvent.Attendees)-1)
-				} else if changedEvent.Attendees != nil {
-					textToPost += fmt.Sprintf("**Guests**: %+v (Organizer) & %v more\n",
-						changedEvent.Organizer.Email, len(changedEvent.Attendees)-1)
-				}
-
-				if oldEvent.Status != changedEvent.Status {
-					hasChange = true
-					textToPost += fmt.Sprintf("**Status of Event**: ~~%s~~ ⟶ %s\n", strings.Title(oldEvent.Status), strings.Title(changedEvent.Status))
-				} else {
-					textToPost += fmt.Sprintf("**Status of Event**: %s\n", strings.Title(changedEvent.Status))
-				}
-
-				self := p.retrieveMyselfForEvent(changedEvent)
-				if self != nil && changedEvent.Status != "cancelled" {
-					if self.ResponseStatus == "needsAction" {
-						// config := p.API.GetConfig()
-						url := fmt.Sprintf("%s/plugins/%s/handleresponse?evtid=%s&",
-							p.getConfiguration().SiteUrl, manifest.ID, changedEvent.Id)
-						textToPost += fmt.Sprintf("**Going?**: [Yes](%s) | [No](%s) | [Maybe](%s)\n\n",
-							url+"response=accepted", url+"response=declined", url+"response=tentative")
-					} else if self.ResponseStatus == "declined" {
-						textToPost += "**Going?**: No\n\n"
-					} else if self.ResponseStatus == "tentative" {
-						textToPost += "**Going?**: Maybe\n\n"
-					} else {
-						textToPost += "**Going?**: Yes\n\n"
-					}
-				}
-
-				// If the event was deleted, we want to remove it from our events slice in our database
-				if changedEvent.Status == "cancelled" {
-					events = append(events[:idx], events[idx+1:]...)
-				} else {
-					// Otherwise we want to replace the old event with the updated event
-					events[idx] = changedEvent
-				}
-
-				break
-			}
+	return events, nil
+}
 
-			// If we couldn't find the event in the database, it must be a new event so we append it
-			// and post a your invited to a users channel
-			if idx == len(events)-1 {
-				if changedEvent.Status != "cancelled" {
-					hasChange = true
-					events = p.insertSort(events, changedEvent)
-					textToPost = "**_You've been invited:_**\n"
-					textToPost += p.printEventSummary(userID, changedEvent)
-				}
-			}
+// dropStoredEvents forgets the synced events of the user, the next sync is a full sync. The threads
+// of the events that are over are pruned first, the full sync doesn't bring these events back
+func (p *Plugin) dropStoredEvents(userID string) error {
+	if err := p.pruneEventThreads(userID); err != nil {
+		p.API.LogError("Error pruning event threads", "err", err.Error(), "userID", userID)
+	}
+	for _, key := range []string{constant.SYNC_TOKEN_KEY, constant.EVENTS_KEY} {
+		if err := p.services.lookupService.Delete(models.LookupsRequest{
+			UserID: userID,
+			Key:    key,
+		}); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// in case no current events
-	if len(events) == 0 {
-		for _, changedEvent := range latestEvents {
-			if changedEvent.Creator.Self {
-				shouldPostMessage = false
-			}
-			if changedEvent.Status == "cancelled" {
-				continue
-			}
-			hasChange = true
-			events = p.insertSort(events, changedEvent)
-			textToPost = "**_You've been invited:_**\n"
-			textToPost += p.printEventSummary(userID, changedEvent)
-		}
+// updateEventsInDatabase applies the changes of an incremental sync to the stored events and notifies
+// the user about all of them at once
+func (p *Plugin) updateEventsInDatabase(userID string, allowNotify string, settings models.UserSettings, latestEvents []*calendar.Event) error {
+	events, err := p.getStoredEvents(userID)
+	if err != nil {
+		return err
 	}
 
-	// newEvents, err := json.Marshal(latestEvents)
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		return err
+	}
+
+	events, changes := p.applyEventChanges(events, latestEvents, location)
+
 	newEvents, err := json.Marshal(events)
 	if err != nil {
 		return err
@@ -462,12 +333,49 @@ func (p *Plugin) updateEventsInDatabase(userID string, allowNotify string, lates
 	}); err != nil {
 		return err
 	}
-	if textToPost != "" && hasChange && shouldPostMessage && allowNotify == constant.ALLOW_NOTIFY {
-		if appErr := p.CreateBotDMPost(userID, textToPost); appErr != nil {
-			return appErr
-		}
+
+	if allowNotify != constant.ALLOW_NOTIFY {
+		return nil
 	}
-	return nil
+	return p.notifyEventChanges(userID, settings, changes, events, location)
+}
+
+// printEventUpdate renders the "Event Updated" post for a changed event, striking through the
+// old values, and reports whether anything worth notifying about has changed. With card, the post
+// only tells what changed and the card of the event shows the rest
+func (p *Plugin) printEventUpdate(oldEvent, changedEvent *calendar.Event, format timeFormat, card bool) (string, bool) {
+	diff := diffEvents(oldEvent, changedEvent, format.location)
+	update := templates.Update{
+		Localized:          templates.Localized{Localizer: format.localizer},
+		Event:              p.newTemplateEvent(changedEvent, format, time.Time{}),
+		Old:                p.newTemplateEvent(oldEvent, format, time.Time{}),
+		TitleChanged:       diff.SummaryChanged,
+		TimeChanged:        diff.TimeChanged,
+		RecurrenceChanged:  diff.RecurrenceChanged,
+		LocationChanged:    diff.LocationChanged,
+		MeetChanged:        diff.MeetLinkChanged,
+		DescriptionChanged: diff.DescriptionChanged,
+		StatusChanged:      diff.StatusChanged,
+	}
+	if diff.DescriptionChanged {
+		update.DescriptionDiff = printTextDiff(oldEvent.Description, changedEvent.Description)
+	}
+	if len(diff.AddedAttendees) > 0 {
+		update.AddedGuests = p.printAttendees(diff.AddedAttendees)
+	}
+	if len(diff.RemovedAttendees) > 0 {
+		update.RemovedGuests = p.printAttendees(diff.RemovedAttendees)
+	}
+	for _, attendee := range diff.Responses {
+		update.Responses = append(update.Responses, p.printResponse(attendee, format.localizer))
+	}
+	// there is nothing left to answer about a cancelled event
+	if changedEvent.Status == constant.EV_STATUS_CANCELLED {
+		update.Event.Response, update.Event.Going = "", ""
+	}
+	update.Event.Card = card
+
+	return p.renderTemplate(templates.UpdateTemplate, update), diff.HasChange()
 }
 
 // func (p *Plugin) setupCalendarWatch(userID string) error {
@@ -574,99 +482,77 @@ func (p *Plugin) remindUserV2(user models.UserDataDto) error {
 	if err != nil {
 		return err
 	}
+	// reminders are sent once, on the minute they are due
+	now := time.Now().In(userLocation)
+	currentMinute := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, userLocation)
+	minutes := cal.userSettings.TimeNotiBeforeEvent
+	allDayReminderTime := cal.userSettings.AllDayReminderTime
+	if allDayReminderTime == "" {
+		allDayReminderTime = constant.DefaultUserSettings.AllDayReminderTime
+	}
+	allDayReminder, err := time.Parse(constant.REMINDER_TIME_FORMAT, allDayReminderTime)
+	if err != nil {
+		return err
+	}
+	muted, err := p.getMutedEvents(user.UserID)
+	if err != nil {
+		p.API.LogError("Error getting muted events", "err", err.Error())
+	}
+
 	for _, event := range events.Items {
-		if p.eventIsOld(user.UserID, event) {
+		et := newEventTime(event, userLocation)
+		if et.IsOver(now) || isEventMuted(muted, event) {
 			continue
 		}
 		self := p.retrieveMyselfForEvent(event)
 		amIAttendingEvent := (p.amIAttendingEvent(self) || event.Creator.Self)
-		if !p.isEventDeleted(event) && amIAttendingEvent {
-			minutes := cal.userSettings.TimeNotiBeforeEvent
-			t := time.Now().In(userLocation).Add(time.Duration(minutes) * time.Minute)
-			minutesLater := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, userLocation).Format(time.RFC3339)
-			if minutesLater == event.Start.DateTime {
-				eventFormatted := p.printEventSummary(user.UserID, event)
-				if appErr := p.CreateBotDMPost(user.UserID, fmt.Sprintf("**_%d minutes until this event:_**\n\n%s", minutes, eventFormatted)); appErr != nil {
-					p.API.LogError("Unable to create bot DM post", "userID", user.UserID)
-					return appErr
-				}
+		if p.isEventDeleted(event) || !amIAttendingEvent {
+			continue
+		}
+
+		reminder := templates.Reminder{AllDay: et.AllDay}
+		var dueAt time.Time
+		if et.AllDay {
+			remindAt := allDayReminderAt(et, allDayReminder, userLocation)
+			if !remindAt.Equal(currentMinute) {
+				continue
+			}
+			dueAt = remindAt
+		} else {
+			if !et.Start.Equal(currentMinute.Add(time.Duration(minutes) * time.Minute)) {
+				continue
 			}
+			dueAt = et.Start.Add(-time.Duration(minutes) * time.Minute)
+			reminder.MinutesBefore = minutes
+		}
+
+		if appErr := p.postReminder(user.UserID, user.Settings, event, reminder); appErr != nil {
+			return appErr
 		}
+		p.metrics.reminderLag.Observe(time.Since(dueAt).Seconds())
 	}
 
 	return nil
 }
 
 func (p *Plugin) printEventSummary(userID string, item *calendar.Event) string {
-	var text string
-	// config := p.API.GetConfig()
-	location, err := p.getPrimaryCalendarLocation(userID)
-	if err != nil {
-		p.API.LogError("Unable to get primary calendar location", "userID", userID)
-		return ""
-	}
-
-	date := time.Now().In(location).Format(constant.DATE_FORMAT)
-	startTime, _ := time.Parse(time.RFC3339, item.Start.DateTime)
-	endTime, _ := time.Parse(time.RFC3339, item.End.DateTime)
-	currentTime := time.Now().In(location).Format(constant.DATE_FORMAT)
-	tomorrowTime := time.Now().AddDate(0, 0, 1).In(location).Format(constant.DATE_FORMAT)
-	dateToDisplay := date
-	if date == currentTime {
-		dateToDisplay = "Today"
-	} else if date == tomorrowTime {
-		dateToDisplay = "Tomorrow"
-	}
-
-	text += fmt.Sprintf("\n**[%v](%s)**\n", item.Summary, item.HtmlLink)
-
-	timeToDisplay := fmt.Sprintf("%v to %v", startTime.Format(constant.TIME_FORMAT), endTime.Format(constant.TIME_FORMAT))
-	if startTime.Format(constant.TIME_FORMAT) == "12:00 AM UTC" && endTime.Format(constant.TIME_FORMAT) == "12:00 AM UTC" {
-		timeToDisplay = "All-day"
-	}
-	text += fmt.Sprintf("**When**: %s @ %s\n", dateToDisplay, timeToDisplay)
-
-	if item.Location != "" {
-		text += fmt.Sprintf("**Where**: %s\n", item.Location)
-	}
-	if item.Location == "" && item.ConferenceData != nil {
-		// text += fmt.Sprintf("**Where**: [%s](%s)\n", item.ConferenceData.ConferenceSolution.Name, item.HangoutLink)
-		text += fmt.Sprintf("**Where**: %s\n", item.HangoutLink)
-	}
-
-	if item.Attendees != nil {
-		text += fmt.Sprintf("**Guests**: %+v (Organizer) & %v more\n", item.Organizer.Email, len(item.Attendees)-1)
-	}
-	text += fmt.Sprintf("**Status of Event**: %s\n", strings.Title(item.Status))
-
-	attendee := p.retrieveMyselfForEvent(item)
-	if attendee != nil {
-		if attendee.ResponseStatus == "needsAction" {
-			url := fmt.Sprintf("%s/plugins/%s/handleresponse?evtid=%s&",
-				p.getConfiguration().SiteUrl, manifest.ID, item.Id)
-			text += fmt.Sprintf("**Going?**: [Yes](%s) | [No](%s) | [Maybe](%s)\n",
-				url+"response=accepted", url+"response=declined", url+"response=tentative")
-		} else if attendee.ResponseStatus == "declined" {
-			text += "**Going?**: No\n"
-		} else if attendee.ResponseStatus == "tentative" {
-			text += "**Going?**: Maybe\n"
-		} else {
-			text += "**Going?**: Yes\n"
-		}
-	}
-
-	if item.Organizer.Self {
-		text += fmt.Sprintf("[Delete Event](%s/plugins/%s/delete?evtid=%s)\n",
-			p.getConfiguration().SiteUrl, manifest.ID, item.Id)
-	}
+	return p.printEventSummaryOnDay(userID, item, time.Time{})
+}
 
-	return text
+// printEventSummaryOnDay prints the event as seen on a particular day, so events covering several days
+// tell which day of the event it is. A zero day prints the event as a whole
+func (p *Plugin) printEventSummaryOnDay(userID string, item *calendar.Event, day time.Time) string {
+	format := p.getTimeFormat(userID)
+	return p.renderTemplate(templates.EventTemplate, p.newTemplateEvent(item, format, day))
 }
 
 func (p *Plugin) getPrimaryCalendarID(userID string) string {
-	cal, _ := p.getCalendarService(userID)
-	primaryCalendar, _ := cal.service.Calendars.Get(constant.PRIMARY_CALENDAR_ID).Do()
-	return primaryCalendar.Id
+	metadata, err := p.getCalendarMetadata(userID)
+	if err != nil {
+		p.API.LogError("Error getting primary calendar", "err", err.Error())
+		return constant.PRIMARY_CALENDAR_ID
+	}
+	return metadata.primaryID
 }
 
 func (p *Plugin) stopWatch(userID string) error {
@@ -700,9 +586,9 @@ func (p *Plugin) stopWatch(userID string) error {
 
 	cal, err := p.getCalendarService(userID)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+		if errors.Is(err, apperr.ErrNotConnected) {
 			// tell user to connect first
-			if appErr := p.CreateBotDMPost(userID, constant.ERR_CONNECT_FIRST); appErr != nil {
+			if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, err)); appErr != nil {
 				p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 				return err
 			}
@@ -720,3 +606,35 @@ func (p *Plugin) stopWatch(userID string) error {
 	}
 	return nil
 }
+
+// printChange strikes through the old value of a field and shows the new one
+func printChange(oldValue, newValue string) string {
+	return fmt.Sprintf("~~%s~~ ⟶ %s", oldValue, newValue)
+}
+
+// printEventStatus prints the status of an event, like Confirmed
+func printEventStatus(status string, format timeFormat) string {
+	switch status {
+	case "confirmed", constant.EV_STATUS_TENTATIVE, constant.EV_STATUS_CANCELLED:
+		return format.T("event.status." + status)
+	default:
+		return strings.Title(status)
+	}
+}
+
+// printGoing prints the response of the user to the event, with the links to answer it when they
+// haven't yet
+func (p *Plugin) printGoing(event *calendar.Event, self *calendar.EventAttendee, format timeFormat) string {
+	switch self.ResponseStatus {
+	case constant.EV_STATUS_NEED_ACTION:
+		url := fmt.Sprintf("%s/plugins/%s/handleresponse?evtid=%s&",
+			p.getConfiguration().SiteUrl, manifest.ID, event.Id)
+		return format.T("event.going.links", url+"response=accepted", url+"response=declined", url+"response=tentative")
+	case constant.EV_STATUS_DECLINED:
+		return format.T("event.going", format.T("response.no"))
+	case constant.EV_STATUS_TENTATIVE:
+		return format.T("event.going", format.T("response.maybe"))
+	default:
+		return format.T("event.going", format.T("response.yes"))
+	}
+}
@@ -0,0 +1,192 @@
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	// calendarServiceTTL is how long the calendar service of a user, with their token and
+	// settings, is reused
+	calendarServiceTTL = 15 * time.Minute
+
+	// calendarMetadataTTL is how long the primary calendar and the calendar list of a user are kept
+	calendarMetadataTTL = time.Hour
+
+	// calendarCacheSize bounds the cache, expired entries are dropped first when it is full
+	calendarCacheSize = 1000
+)
+
+// calendarMetadata is what the plugin needs to know about the calendars of a user
+type calendarMetadata struct {
+	primaryID   string
+	primaryName string
+	location    *time.Location
+	expires     time.Time
+
+	// calendars is only fetched when it is needed
+	calendars        []*calendar.CalendarListEntry
+	calendarsExpires time.Time
+}
+
+type calendarCacheEntry struct {
+	service        *CalendarService
+	serviceExpires time.Time
+	metadata       *calendarMetadata
+}
+
+// calendarCache keeps the calendar service and the calendar metadata of the users, so the token
+// is decrypted and the primary calendar is fetched once in a while instead of on every call
+type calendarCache struct {
+	mutex   sync.Mutex
+	entries map[string]*calendarCacheEntry
+}
+
+func newCalendarCache() *calendarCache {
+	return &calendarCache{entries: make(map[string]*calendarCacheEntry)}
+}
+
+func (c *calendarCache) getService(userID string, now time.Time) *CalendarService {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[userID]
+	if !ok || entry.service == nil || !now.Before(entry.serviceExpires) {
+		return nil
+	}
+	return entry.service
+}
+
+func (c *calendarCache) setService(userID string, service *CalendarService, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry := c.entry(userID, now)
+	entry.service = service
+	entry.serviceExpires = now.Add(calendarServiceTTL)
+}
+
+func (c *calendarCache) getMetadata(userID string, now time.Time) *calendarMetadata {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[userID]
+	if !ok || entry.metadata == nil || !now.Before(entry.metadata.expires) {
+		return nil
+	}
+	return entry.metadata
+}
+
+func (c *calendarCache) setMetadata(userID string, metadata *calendarMetadata, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entry(userID, now).metadata = metadata
+}
+
+func (c *calendarCache) getCalendars(userID string, now time.Time) []*calendar.CalendarListEntry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[userID]
+	if !ok || entry.metadata == nil || !now.Before(entry.metadata.calendarsExpires) {
+		return nil
+	}
+	return entry.metadata.calendars
+}
+
+func (c *calendarCache) setCalendars(userID string, calendars []*calendar.CalendarListEntry, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[userID]
+	if !ok || entry.metadata == nil {
+		return
+	}
+	entry.metadata.calendars = calendars
+	entry.metadata.calendarsExpires = now.Add(calendarMetadataTTL)
+}
+
+// invalidate forgets everything about the user, when their token or settings change or when they
+// disconnect
+func (c *calendarCache) invalidate(userID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, userID)
+}
+
+// entry returns the entry of the user, making room for it when the cache is full. The mutex must
+// be held
+func (c *calendarCache) entry(userID string, now time.Time) *calendarCacheEntry {
+	if entry, ok := c.entries[userID]; ok {
+		return entry
+	}
+	if len(c.entries) >= calendarCacheSize {
+		for k, e := range c.entries {
+			if !now.Before(e.serviceExpires) && (e.metadata == nil || !now.Before(e.metadata.expires)) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= calendarCacheSize {
+			c.entries = make(map[string]*calendarCacheEntry)
+		}
+	}
+	entry := &calendarCacheEntry{}
+	c.entries[userID] = entry
+	return entry
+}
+
+// getCalendarMetadata returns the primary calendar ID and time zone of the user, from the cache
+// when they were fetched recently
+func (p *Plugin) getCalendarMetadata(userID string) (*calendarMetadata, error) {
+	now := time.Now()
+	if metadata := p.calendarCache.getMetadata(userID, now); metadata != nil {
+		return metadata, nil
+	}
+
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		return nil, err
+	}
+	primaryCalendar, err := cal.service.Calendars.Get(constant.PRIMARY_CALENDAR_ID).Do()
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(primaryCalendar.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &calendarMetadata{
+		primaryID:   primaryCalendar.Id,
+		primaryName: primaryCalendar.Summary,
+		location:    location,
+		expires:     now.Add(calendarMetadataTTL),
+	}
+	p.calendarCache.setMetadata(userID, metadata, now)
+	return metadata, nil
+}
+
+// getCalendarList returns the calendars the user has in their list, from the cache when they were
+// fetched recently
+func (p *Plugin) getCalendarList(userID string) ([]*calendar.CalendarListEntry, error) {
+	now := time.Now()
+	if calendars := p.calendarCache.getCalendars(userID, now); calendars != nil {
+		return calendars, nil
+	}
+	if _, err := p.getCalendarMetadata(userID); err != nil {
+		return nil, err
+	}
+
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		return nil, err
+	}
+	var calendars []*calendar.CalendarListEntry
+	err = cal.service.CalendarList.List().Pages(p.ctx, func(list *calendar.CalendarList) error {
+		calendars = append(calendars, list.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.calendarCache.setCalendars(userID, calendars, now)
+	return calendars, nil
+}
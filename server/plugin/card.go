@@ -0,0 +1,233 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"google.golang.org/api/calendar/v3"
+)
+
+// newEventCard builds the card shown under the posts about an event: its time, place, organizer
+// and guests, colored by the response of the user, with the buttons to join, answer or delete it.
+// A zero day shows the event as a whole
+func (p *Plugin) newEventCard(userID string, item *calendar.Event, format timeFormat, day time.Time) *model.SlackAttachment {
+	event := p.newTemplateEvent(item, format, day)
+	card := &model.SlackAttachment{
+		Fallback:  fmt.Sprintf("%s - %s", item.Summary, event.When),
+		Color:     eventCardColor(item, event.Response),
+		Title:     item.Summary,
+		TitleLink: item.HtmlLink,
+		Footer:    "Google Calendar",
+	}
+	if metadata, err := p.getCalendarMetadata(userID); err == nil && metadata.primaryName != "" {
+		card.Footer += " · " + metadata.primaryName
+	}
+
+	card.Fields = append(card.Fields, &model.SlackAttachmentField{Title: format.T("card.when"), Value: event.When})
+	if event.Where != "" {
+		card.Fields = append(card.Fields, &model.SlackAttachmentField{Title: format.T("card.where"), Value: event.Where, Short: true})
+	}
+	if item.Organizer != nil {
+		organizer := &calendar.EventAttendee{Email: item.Organizer.Email, DisplayName: item.Organizer.DisplayName}
+		card.Fields = append(card.Fields, &model.SlackAttachmentField{Title: format.T("card.organizer"), Value: p.printAttendee(organizer), Short: true})
+	}
+	if counts := event.GuestCounts; counts.Yes+counts.No+counts.Maybe+counts.Awaiting > 0 {
+		card.Fields = append(card.Fields, &model.SlackAttachmentField{
+			Title: format.T("card.guests"),
+			Value: format.T("card.guests.counts", counts.Yes, counts.No, counts.Maybe, counts.Awaiting),
+		})
+	}
+
+	// nothing can be done about a cancelled event anymore
+	if p.isEventDeleted(item) {
+		return card
+	}
+	if event.Meet != "" {
+		card.Actions = append(card.Actions, p.newEventAction("/event/action", format.T("card.join"), "primary", item.Id, map[string]interface{}{
+			"action": constant.EVENT_ACTION_JOIN,
+			"url":    event.Meet,
+		}))
+	}
+	if event.Response != "" {
+		for _, answer := range []struct {
+			name     string
+			response string
+		}{
+			{format.T("response.yes"), constant.EV_STATUS_ACCEPTED},
+			{format.T("response.no"), constant.EV_STATUS_DECLINED},
+			{format.T("response.maybe"), constant.EV_STATUS_TENTATIVE},
+		} {
+			style := "default"
+			if answer.response == event.Response {
+				style = "good"
+			}
+			card.Actions = append(card.Actions, p.newEventAction("/event/action", answer.name, style, item.Id, map[string]interface{}{
+				"action":   constant.EVENT_ACTION_RESPOND,
+				"response": answer.response,
+			}))
+		}
+	}
+	if event.Organizer {
+		card.Actions = append(card.Actions, p.newEventAction("/event/action", format.T("card.delete"), "danger", item.Id, map[string]interface{}{
+			"action": constant.EVENT_ACTION_DELETE,
+		}))
+	}
+	return card
+}
+
+// newEventAction returns a button about an event, handled by the plugin route at path
+func (p *Plugin) newEventAction(path, name, style, eventID string, context map[string]interface{}) *model.PostAction {
+	context["evtid"] = eventID
+	return &model.PostAction{
+		Name:  name,
+		Type:  model.POST_ACTION_TYPE_BUTTON,
+		Style: style,
+		Integration: &model.PostActionIntegration{
+			URL:     fmt.Sprintf("%s/plugins/%s%s", p.getConfiguration().SiteUrl, manifest.ID, path),
+			Context: context,
+		},
+	}
+}
+
+// eventCardColor colors the card by the response of the user, the events they organize or just
+// have in their calendar count as accepted
+func eventCardColor(item *calendar.Event, response string) string {
+	if item.Status == constant.EV_STATUS_CANCELLED {
+		return constant.EVENT_CARD_COLOR_CANCELLED
+	}
+	switch response {
+	case constant.EV_STATUS_DECLINED:
+		return constant.EVENT_CARD_COLOR_DECLINED
+	case constant.EV_STATUS_TENTATIVE:
+		return constant.EVENT_CARD_COLOR_TENTATIVE
+	case constant.EV_STATUS_NEED_ACTION:
+		return constant.EVENT_CARD_COLOR_NEED_ACTION
+	default:
+		return constant.EVENT_CARD_COLOR_ACCEPTED
+	}
+}
+
+// handleEventCardAction handles the buttons of the event cards. Answering or deleting the event
+// redraws its card in the post
+func (p *Plugin) handleEventCardAction(w http.ResponseWriter, r *http.Request) {
+	var req model.PostActionIntegrationRequest
+	if err := Decode(r.Body, &req); err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+
+	userID, ok := getActionUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	response := &model.PostActionIntegrationResponse{}
+	defer func() {
+		if err := Encode(w, response); err != nil {
+			p.API.LogError("Error encoding action response", "err", err.Error())
+		}
+	}()
+
+	localizer := p.getLocalizer(userID)
+	action, _ := req.Context["action"].(string)
+	eventID, _ := req.Context["evtid"].(string)
+	// Mattermost buttons can't open links, the link to join is sent to the user instead
+	if action == constant.EVENT_ACTION_JOIN {
+		url, _ := req.Context["url"].(string)
+		response.EphemeralText = localizer.T("card.join.link", url)
+		return
+	}
+
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			response.EphemeralText = p.userMessage(userID, err)
+			return
+		}
+
+		p.API.LogError("Error getting calendar service", "err", err.Error())
+		return
+	}
+
+	calendarID := p.getPrimaryCalendarID(userID)
+	event, err := cal.service.Events.Get(calendarID, eventID).Do()
+	if err != nil {
+		response.EphemeralText = localizer.T("event.not_found", eventID)
+		return
+	}
+
+	switch action {
+	case constant.EVENT_ACTION_RESPOND:
+		answer, _ := req.Context["response"].(string)
+		for _, attendee := range event.Attendees {
+			if attendee.Self {
+				attendee.ResponseStatus = answer
+			}
+		}
+		updated, err := cal.service.Events.Update(calendarID, eventID, event).Do()
+		if err != nil {
+			p.API.LogError("Error updating event response", "err", err.Error())
+			response.EphemeralText = localizer.T("event.respond.failed", err)
+			return
+		}
+		event = updated
+		response.EphemeralText = localizer.T("event.respond.success", event.Summary)
+
+	case constant.EVENT_ACTION_DELETE:
+		if event.Organizer == nil || !event.Organizer.Self {
+			response.EphemeralText = p.userMessage(userID, apperr.NotOrganizer("delete"))
+			return
+		}
+		if err := cal.service.Events.Delete(calendarID, eventID).Do(); err != nil {
+			p.API.LogError("Error deleting event", "err", err.Error())
+			response.EphemeralText = localizer.T("event.delete.failed", err)
+			return
+		}
+		event.Status = constant.EV_STATUS_CANCELLED
+		response.EphemeralText = localizer.T("event.delete.success", event.Summary)
+
+	default:
+		p.API.LogError("Unknown event card action", "action", action)
+		return
+	}
+
+	if post := p.replaceEventCard(userID, req.PostId, event); post != nil {
+		response.Update = post
+	}
+}
+
+// replaceEventCard redraws the card of the event in a post, other cards of the post are left
+// alone. It returns nil when the post has no card of the event
+func (p *Plugin) replaceEventCard(userID, postID string, event *calendar.Event) *model.Post {
+	post, appErr := p.API.GetPost(postID)
+	if appErr != nil {
+		p.API.LogError("Error getting post of event card", "err", appErr.Error())
+		return nil
+	}
+
+	format := p.getTimeFormat(userID)
+	attachments := post.Attachments()
+	replaced := false
+	for i, attachment := range attachments {
+		if attachment.TitleLink != event.HtmlLink {
+			continue
+		}
+		// the card of a reminder keeps its buttons
+		if isReminderCard(attachment) && !p.isEventDeleted(event) {
+			attachments[i] = p.newReminderCard(userID, event, format)
+		} else {
+			attachments[i] = p.newEventCard(userID, event, format, time.Time{})
+		}
+		replaced = true
+	}
+	if !replaced {
+		return nil
+	}
+	model.ParseSlackAttachment(post, attachments)
+	return post
+}
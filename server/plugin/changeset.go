@@ -0,0 +1,320 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/templates"
+	"google.golang.org/api/calendar/v3"
+)
+
+// eventChange is the change of one event brought by a sync, oldEvent is nil for new events. text
+// is posted with the other changes, cardText and card when the change is posted on its own
+type eventChange struct {
+	event    *calendar.Event
+	oldEvent *calendar.Event
+	text     string
+	cardText string
+	card     *model.SlackAttachment
+}
+
+// eventChangeSet groups the changes of one sync
+type eventChangeSet struct {
+	Added       []eventChange
+	Updated     []eventChange
+	Cancelled   []eventChange
+	RSVPChanged []eventChange
+}
+
+// subject returns the event the change is about. Google only guarantees the ID of a cancelled
+// event, so it is shown as it was before, with its new status
+func (c eventChange) subject() *calendar.Event {
+	if c.oldEvent == nil || c.event.Status != constant.EV_STATUS_CANCELLED {
+		return c.event
+	}
+	event := *c.oldEvent
+	event.Status = c.event.Status
+	return &event
+}
+
+func (c eventChangeSet) all() []eventChange {
+	var changes []eventChange
+	changes = append(changes, c.Added...)
+	changes = append(changes, c.Updated...)
+	changes = append(changes, c.Cancelled...)
+	return append(changes, c.RSVPChanged...)
+}
+
+// without returns the changes, except those matching skip
+func (c eventChangeSet) without(skip func(eventChange) bool) eventChangeSet {
+	filter := func(changes []eventChange) []eventChange {
+		var kept []eventChange
+		for _, change := range changes {
+			if !skip(change) {
+				kept = append(kept, change)
+			}
+		}
+		return kept
+	}
+	return eventChangeSet{
+		Added:       filter(c.Added),
+		Updated:     filter(c.Updated),
+		Cancelled:   filter(c.Cancelled),
+		RSVPChanged: filter(c.RSVPChanged),
+	}
+}
+
+func (c eventChangeSet) isEmpty() bool {
+	return len(c.all()) == 0
+}
+
+// applyEventChanges applies the changed events on the stored events and sorts every change in its
+// bucket. Each change is evaluated on its own
+func (p *Plugin) applyEventChanges(events []*calendar.Event, latestEvents []*calendar.Event, location *time.Location) ([]*calendar.Event, eventChangeSet) {
+	var changes eventChangeSet
+	for _, changedEvent := range latestEvents {
+		idx := -1
+		for i, event := range events {
+			if event.Id == changedEvent.Id {
+				idx = i
+				break
+			}
+		}
+
+		// If we couldn't find the event in the database, it must be a new event
+		if idx == -1 {
+			if p.isEventDeleted(changedEvent) {
+				continue
+			}
+			events = p.insertSort(events, changedEvent, location)
+			changes.Added = append(changes.Added, eventChange{event: changedEvent})
+			continue
+		}
+
+		oldEvent := events[idx]
+		change := eventChange{event: changedEvent, oldEvent: oldEvent}
+
+		// If the event was deleted, we want to remove it from our events slice in our database
+		if p.isEventDeleted(changedEvent) {
+			events = append(events[:idx], events[idx+1:]...)
+			changes.Cancelled = append(changes.Cancelled, change)
+			continue
+		}
+
+		// Otherwise we want to replace the old event with the updated event, keeping the order
+		events = append(events[:idx], events[idx+1:]...)
+		events = p.insertSort(events, changedEvent, location)
+
+		if diff := diffEvents(oldEvent, changedEvent, location); diff.HasChange() {
+			changes.Updated = append(changes.Updated, change)
+		} else if len(diff.Responses) > 0 {
+			changes.RSVPChanged = append(changes.RSVPChanged, change)
+		}
+	}
+	return events, changes
+}
+
+// isOwnEvent tells whether the user created the event the change is about
+func isOwnEvent(change eventChange) bool {
+	event := change.subject()
+	return event.Creator != nil && event.Creator.Self
+}
+
+// notifiedChanges returns the changes the user wants to hear about. The user doesn't need to hear
+// about the changes of the events they created, they made them, but the answers of their guests
+// are still news to them: an update of such an event bringing some is kept as an RSVP change
+func notifiedChanges(changes eventChangeSet, location *time.Location) eventChangeSet {
+	notified := changes.without(isOwnEvent)
+	notified.RSVPChanged = append([]eventChange(nil), changes.RSVPChanged...)
+	for _, change := range changes.Updated {
+		if isOwnEvent(change) && len(diffEvents(change.oldEvent, change.event, location).Responses) > 0 {
+			notified.RSVPChanged = append(notified.RSVPChanged, change)
+		}
+	}
+	return notified
+}
+
+// printEventChanges renders every change the user should hear about
+func (p *Plugin) printEventChanges(userID string, settings models.UserSettings, changes eventChangeSet, events []*calendar.Event, location *time.Location) eventChangeSet {
+	var printed eventChangeSet
+	format := p.getTimeFormat(userID)
+	muted, err := p.getMutedEvents(userID)
+	if err != nil {
+		p.API.LogError("Error getting muted events", "err", err.Error())
+	}
+	changes = notifiedChanges(changes, location)
+	changes = changes.without(func(change eventChange) bool { return isEventMuted(muted, change.subject()) })
+	for _, change := range changes.Added {
+		invite := templates.Invite{
+			Localized: templates.Localized{Localizer: format.localizer},
+			Event:     p.newTemplateEvent(change.event, format, time.Time{}),
+		}
+		if conflicts := p.findOverlappingEvents(change.event, events, location); len(conflicts) > 0 {
+			invite.Conflicts = p.printConflictWarning(change.event, conflicts, format)
+		}
+		change.text = p.renderTemplate(templates.InviteTemplate, invite)
+		invite.Event.Card = true
+		change.cardText = p.renderTemplate(templates.InviteTemplate, invite)
+		change.card = p.newEventCard(userID, change.event, format, time.Time{})
+		printed.Added = append(printed.Added, change)
+	}
+	for _, change := range changes.Updated {
+		change.text, _ = p.printEventUpdate(change.oldEvent, change.event, format, false)
+		change.cardText, _ = p.printEventUpdate(change.oldEvent, change.event, format, true)
+		change.card = p.newEventCard(userID, change.event, format, time.Time{})
+		printed.Updated = append(printed.Updated, change)
+	}
+	for _, change := range changes.Cancelled {
+		change.text = p.renderTemplate(templates.CancelTemplate, templates.Cancel{
+			Localized: templates.Localized{Localizer: format.localizer},
+			Event:     p.newTemplateEvent(change.subject(), format, time.Time{}),
+		})
+		printed.Cancelled = append(printed.Cancelled, change)
+	}
+	// the organizer only hears about guests declining, and only when they asked to
+	for _, change := range changes.RSVPChanged {
+		if !settings.NotifyOnDecline {
+			break
+		}
+		var declined []*calendar.EventAttendee
+		for _, attendee := range diffEvents(change.oldEvent, change.event, location).Responses {
+			if attendee.ResponseStatus == constant.EV_STATUS_DECLINED {
+				declined = append(declined, attendee)
+			}
+		}
+		if len(declined) == 0 {
+			continue
+		}
+		change.text = format.T("changes.declined", change.event.Summary, change.event.HtmlLink)
+		for _, attendee := range declined {
+			change.text += fmt.Sprintf("- %s\n", p.printResponse(attendee, format.localizer))
+		}
+		printed.RSVPChanged = append(printed.RSVPChanged, change)
+	}
+	return printed
+}
+
+// notifyEventChanges posts the changes of a sync, either in one consolidated post or in the thread
+// of each event depending on the user's settings. A lone change always goes to its event thread
+func (p *Plugin) notifyEventChanges(userID string, settings models.UserSettings, changes eventChangeSet, events []*calendar.Event, location *time.Location) error {
+	printed := p.printEventChanges(userID, settings, changes, events, location)
+	if printed.isEmpty() {
+		return nil
+	}
+
+	// during the quiet hours or in digest mode, only the changes of the events about to start are
+	// sent now, the others wait for the digest
+	now := time.Now().In(p.getTimeFormat(userID).location)
+	if isQuietHours(settings, now) || digestMode(settings) != constant.DIGEST_MODE_OFF {
+		urgent := func(change eventChange) bool { return isImminentEvent(change.subject(), now) }
+		if err := p.queueNotifications(userID, printed.without(urgent), now); err != nil {
+			p.API.LogError("Error queueing notifications, sending them now", "err", err.Error())
+		} else {
+			printed = printed.without(func(change eventChange) bool { return !urgent(change) })
+		}
+		if printed.isEmpty() {
+			return nil
+		}
+	}
+
+	inviteDelivery := notificationDelivery(settings, constant.NOTIFICATION_INVITES)
+	updateDelivery := notificationDelivery(settings, constant.NOTIFICATION_UPDATES)
+	if inviteDelivery == updateDelivery {
+		return p.postEventChanges(userID, settings, printed, inviteDelivery)
+	}
+
+	// invites and updates delivered apart are posted apart
+	updates := printed
+	updates.Added = nil
+	if err := p.postEventChanges(userID, settings, eventChangeSet{Added: printed.Added}, inviteDelivery); err != nil {
+		return err
+	}
+	return p.postEventChanges(userID, settings, updates, updateDelivery)
+}
+
+// postEventChanges posts the printed changes to the delivery, in one post or in the threads of the
+// events as the user chose
+func (p *Plugin) postEventChanges(userID string, settings models.UserSettings, printed eventChangeSet, delivery string) error {
+	all := printed.all()
+	if len(all) == 0 {
+		return nil
+	}
+	if len(all) == 1 || settings.SyncNotificationLayout == constant.SYNC_LAYOUT_THREADS {
+		for _, change := range all {
+			text := change.text
+			if change.card != nil {
+				text = change.cardText
+			}
+			if appErr := p.CreateBotNotificationEventPost(userID, delivery, change.subject(), text, change.card); appErr != nil {
+				return appErr
+			}
+		}
+		return nil
+	}
+
+	localizer := p.getLocalizer(userID)
+	for _, text := range printChangePosts(localizer.T("changes.title", len(all)), printed, localizer) {
+		if appErr := p.CreateBotNotificationPost(userID, delivery, text, nil); appErr != nil {
+			return appErr
+		}
+	}
+	return nil
+}
+
+// changeSection is one section of the combined posts, named as its title in the catalogs
+type changeSection struct {
+	name    string
+	changes []eventChange
+}
+
+// sections returns the changes by section, in the order they are posted
+func (c eventChangeSet) sections() []changeSection {
+	return []changeSection{
+		{"added", c.Added},
+		{"updated", c.Updated},
+		{"cancelled", c.Cancelled},
+		{"responses", c.RSVPChanged},
+	}
+}
+
+// add puts the change in the named section
+func (c *eventChangeSet) add(section string, change eventChange) {
+	switch section {
+	case "added":
+		c.Added = append(c.Added, change)
+	case "cancelled":
+		c.Cancelled = append(c.Cancelled, change)
+	case "responses":
+		c.RSVPChanged = append(c.RSVPChanged, change)
+	default:
+		c.Updated = append(c.Updated, change)
+	}
+}
+
+// printChangePosts prints the title then the text of the changes under the titles of their
+// sections, split in as many posts as needed to fit in the post size limit like the agenda
+func printChangePosts(title string, changes eventChangeSet, localizer *i18n.Localizer) []string {
+	var posts []string
+	current := title
+	for _, section := range changes.sections() {
+		if len(section.changes) == 0 {
+			continue
+		}
+		name := localizer.T("changes.section." + section.name)
+		current += fmt.Sprintf("\n##### %s (%d)\n", name, len(section.changes))
+		for _, change := range section.changes {
+			text := change.text + "\n"
+			if utf8.RuneCountInString(current)+utf8.RuneCountInString(text) > agendaPostLimit {
+				posts = append(posts, current)
+				current = fmt.Sprintf("\n##### %s (%d)\n", localizer.T("agenda.continued", name), len(section.changes))
+			}
+			current += text
+		}
+	}
+	return append(posts, current)
+}
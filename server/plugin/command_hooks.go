@@ -11,49 +11,11 @@ import (
 	"github.com/mattermost/mattermost-server/v5/plugin"
 	"github.com/pkg/errors"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
 	"google.golang.org/api/calendar/v3"
 )
 
-// CommandHelp - about
-const CommandHelp = `* |/calendar connect| - Connect your Google Calendar with your Mattermost account
-
----
-
-* |/calendar create| Create a event with a title, start date-time, end date-time, and attendees (attendees are optional) - This command will create Google Meeting automatically
-	* |Title| can be any title you like for the event.
-	* |Start DateTime| This is the time the event starts. It should be a date and time in the format of YYYY-MM-DD@HH:MM in 24 hour time format.
-		* Example: 2022-01-01@12:00
-	* |End DateTime| This is the time the event ends. It should be a date and time in the format of YYYY-MM-DD@HH:MM in 24 hour time format.
-		* Example: 2022-01-01@13:00
-	* |Attendees - Optional| This is a list of username or email addresses of the people you want to invite to the event. You have to type within square brackets "[ ]" and separate each username or email address with space.
-		* Example: [@exampleuser-1, @exampleuser-2 example@gmail.com ...]
-	**Full command Example:** => | /calendar create 'my-meeting' 2022-01-01@12:00 2022-01-01@13:00 [exampleuser-1 exampleuser-2 example@gmail.com] |
-
----
-
-* |/calendar summary [date]| - Get a break down of a particular date.
-	* |date| can be word 'today' or 'tmr' or specific date in YYYY-MM-DD format.
-		* Example: [@exampleuser-1, @exampleuser-2, example@gmail.com ...]
-	**Full command Example:** => | /calendar summary today |  | /calendar summary tmr |  | /calendar summary 2022-01-01 |
-
----
-
-* |/calendar settings| - User settings, you can see and change your settings.
-	* |You can select these to set configuration|
-		* |Allow notifications| Allow calendar to notify you in the channel.
-		* |Time notify before event| This is the time before event to notify. It must be an positive integer in minute (This will effect when you allow to notify)
-
----
-
-* |/calendar next| - Get the next event of today
-
---- 
-
-* |/calendar disconnect| - Disconnect Google Calendar from your Mattermost account
-
----
-`
-
 func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
 	split := strings.Fields(args.Command)
 	command := split[0]
@@ -74,11 +36,13 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 			p.postCommandResponse(args, "Invalid SiteURL")
 			return &model.CommandResponse{}, nil
 		}
-		txt := fmt.Sprintf("[Click here to link your Google Calendar.](%s/plugins/%s/oauth/connect)", p.getConfiguration().SiteUrl, manifest.ID)
+		txt := p.getLocalizer(args.UserId).T("connect.link", fmt.Sprintf("%s/plugins/%s/oauth/connect", p.getConfiguration().SiteUrl, manifest.ID))
 		p.postCommandResponse(args, txt)
 		return &model.CommandResponse{}, nil
 	case constant.CREATE_CMD:
 		messageToPost = p.executeCommandCreate(args)
+	case constant.EDIT_CMD:
+		messageToPost = p.executeCommandEdit(args)
 	case constant.SUMMARY_CMD:
 		messageToPost = p.executeCommandSummary(args)
 	case constant.HELP_CMD:
@@ -87,10 +51,22 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 		messageToPost = p.executeCommandSettings(args)
 	case constant.NEXT_CMD:
 		messageToPost = p.executeCommandNext(args)
+	case constant.AGENDA_CMD:
+		messageToPost = p.executeCommandAgenda(args)
+	case constant.CONFLICTS_CMD:
+		messageToPost = p.executeCommandConflicts(args)
+	case constant.SEARCH_CMD:
+		messageToPost = p.executeCommandSearch(args)
+	case constant.RESPONSES_CMD:
+		messageToPost = p.executeCommandResponses(args)
+	case constant.TEAM_CMD:
+		messageToPost = p.executeCommandTeam(args)
+	case constant.ADMIN_CMD:
+		messageToPost = p.executeCommandAdmin(args)
 	case constant.DISCONNECT_CMD:
 		messageToPost = p.executeCommandDisconnect(args)
 	default:
-		messageToPost = fmt.Sprintf("Unknown command: `%v`", action)
+		messageToPost = p.getLocalizer(args.UserId).T("command.unknown", action)
 	}
 
 	if messageToPost != "" {
@@ -106,52 +82,97 @@ func (p *Plugin) getCommand() (*model.Command, error) {
 		p.API.LogError("failed to load icon data", "err", err.Error())
 		return nil, errors.Wrap(err, "failed to get icon data")
 	}
+	localizer := p.getServerLocalizer()
 	return &model.Command{
 		Trigger:              "calendar",
 		DisplayName:          "Google Calendar",
-		Description:          "Integration with Google Calendar",
+		Description:          localizer.T("command.description"),
 		AutoComplete:         true,
-		AutoCompleteDesc:     "Available commands: connect, create, next, summary, settings, disconnect, help",
+		AutoCompleteDesc:     localizer.T("autocomplete.calendar"),
 		AutoCompleteHint:     "[command]",
-		AutocompleteData:     getAutocompleteData(),
+		AutocompleteData:     getAutocompleteData(localizer),
 		AutocompleteIconData: iconData,
 	}, nil
 }
 
-func getAutocompleteData() *model.AutocompleteData {
-	cal := model.NewAutocompleteData("calendar", "[command]", "Available commands: connect, list, summary, create, help")
+func getAutocompleteData(localizer *i18n.Localizer) *model.AutocompleteData {
+	cal := model.NewAutocompleteData("calendar", "[command]", localizer.T("autocomplete.calendar"))
 
-	connect := model.NewAutocompleteData("connect", "", "Connect your Google Calendar with your Mattermost account")
+	connect := model.NewAutocompleteData("connect", "", localizer.T("autocomplete.connect"))
 	cal.AddCommand(connect)
 
-	create := model.NewAutocompleteData("create", "", "Create an event with a title, Start DateTime, End DateTime, and Attendees")
-	create.AddTextArgument("Title for the event you are creating, must be surrounded by single-quotes. Example: 'my-meeting'", "[title]", "")
-	create.AddTextArgument("Time the event starts in YYYY-MM-DD@HH:MM format (2022-01-01@12:00).", "[start dateTime]", "")
-	create.AddTextArgument("Time the event finishes in YYYY-MM-DD@HH:MM format (2022-01-01@13:00).", "[end dateTime]", "")
-	create.AddTextArgument("This is a list of username or email addresses of the people you want to invite to the event. You have to type within square brackets `[ ]` and separate each username or email address with space.\n Example: [example1 example2 example@gmail.com]", "[Attendees] - Optional", "")
+	create := model.NewAutocompleteData("create", "", localizer.T("autocomplete.create"))
+	create.AddTextArgument(localizer.T("autocomplete.create.title"), "[title]", "")
+	create.AddTextArgument(localizer.T("autocomplete.create.start"), "[start dateTime]", "")
+	create.AddTextArgument(localizer.T("autocomplete.create.end"), "[end dateTime]", "")
+	create.AddTextArgument(localizer.T("autocomplete.create.attendees"), "[Attendees] - Optional", "")
+	create.AddTextArgument(localizer.T("autocomplete.create.force"), "[--force] - Optional", "")
 	cal.AddCommand(create)
 
-	next := model.NewAutocompleteData("next", "", "Get the next event of today")
+	edit := model.NewAutocompleteData("edit", "[event]", localizer.T("autocomplete.edit"))
+	edit.AddDynamicListArgument(localizer.T("autocomplete.event"), fmt.Sprintf("/plugins/%s/autocomplete/events", manifest.ID), true)
+	cal.AddCommand(edit)
+
+	next := model.NewAutocompleteData("next", "", localizer.T("autocomplete.next"))
 	cal.AddCommand(next)
 
-	summary := model.NewAutocompleteData("summary", "[date]", "Get a breakdown of a particular date")
-	summary.AddTextArgument("Date can be a word [today] or [tmr] or specific date in YYYY-MM-DD format (2022-01-01)", "[today] | [tmr] | [date]", "")
+	summary := model.NewAutocompleteData("summary", "[date]", localizer.T("autocomplete.summary"))
+	summary.AddTextArgument(localizer.T("autocomplete.summary.date"), "[today] | [tmr] | [date]", "")
 	// summary.SubCommands = append(summary.SubCommands, model.NewAutocompleteData("today", "", "Today's summary"), model.NewAutocompleteData("tmr", "", "Tomorrow's summary"))
 	cal.AddCommand(summary)
 
-	settings := model.NewAutocompleteData("settings", "", "User settings, you can see and change your settings.")
+	agenda := model.NewAutocompleteData("agenda", "[range] [compact]", localizer.T("autocomplete.agenda"))
+	agenda.AddTextArgument(localizer.T("autocomplete.agenda.range"), "[this week] | [next week] | [start..end] [compact]", "")
+	cal.AddCommand(agenda)
+
+	conflicts := model.NewAutocompleteData("conflicts", "[range]", localizer.T("autocomplete.conflicts"))
+	conflicts.AddTextArgument(localizer.T("autocomplete.conflicts.range"), "[this week] | [next week] | [start..end]", "")
+	cal.AddCommand(conflicts)
+
+	search := model.NewAutocompleteData("search", "<text> [--from date] [--to date] [--attendee @user]", localizer.T("autocomplete.search"))
+	search.AddTextArgument(localizer.T("autocomplete.search.text"), "<text>", "")
+	search.AddNamedTextArgument("from", localizer.T("autocomplete.search.from"), "[date]", "", false)
+	search.AddNamedTextArgument("to", localizer.T("autocomplete.search.to"), "[date]", "", false)
+	search.AddNamedTextArgument("attendee", localizer.T("autocomplete.search.attendee"), "[@user]", "", false)
+	cal.AddCommand(search)
+
+	responses := model.NewAutocompleteData("responses", "[event]", localizer.T("autocomplete.responses"))
+	responses.AddDynamicListArgument(localizer.T("autocomplete.event"), fmt.Sprintf("/plugins/%s/autocomplete/events", manifest.ID), true)
+	cal.AddCommand(responses)
+
+	team := model.NewAutocompleteData("team", "[~channel | @group | subscribe | unsubscribe]", localizer.T("autocomplete.team"))
+	team.AddTextArgument(localizer.T("autocomplete.team.target"), "[~channel] | [@group] | [subscribe] | [unsubscribe]", "")
+	cal.AddCommand(team)
+
+	admin := model.NewAutocompleteData("admin", "[subcommand]", localizer.T("autocomplete.admin"))
+	admin.RoleID = model.SYSTEM_ADMIN_ROLE_ID
+	admin.AddCommand(model.NewAutocompleteData("stats", "", localizer.T("autocomplete.admin.stats")))
+	adminUser := model.NewAutocompleteData("user", "[@user]", localizer.T("autocomplete.admin.user"))
+	adminUser.AddTextArgument(localizer.T("autocomplete.admin.username"), "[@user]", "")
+	admin.AddCommand(adminUser)
+	adminResync := model.NewAutocompleteData("resync", "[@user | all]", localizer.T("autocomplete.admin.resync"))
+	adminResync.AddTextArgument(localizer.T("autocomplete.admin.resync.target"), "[@user] | [all]", "")
+	admin.AddCommand(adminResync)
+	adminDisconnect := model.NewAutocompleteData("disconnect", "[@user]", localizer.T("autocomplete.admin.disconnect"))
+	adminDisconnect.AddTextArgument(localizer.T("autocomplete.admin.username"), "[@user]", "")
+	admin.AddCommand(adminDisconnect)
+	admin.AddCommand(model.NewAutocompleteData("renew-watches", "", localizer.T("autocomplete.admin.renew_watches")))
+	cal.AddCommand(admin)
+
+	settings := model.NewAutocompleteData("settings", "", localizer.T("autocomplete.settings"))
 	cal.AddCommand(settings)
 
-	discon := model.NewAutocompleteData("disconnect", "", "Disconnect Google Calendar from your Mattermost account.")
+	discon := model.NewAutocompleteData("disconnect", "", localizer.T("autocomplete.disconnect"))
 	cal.AddCommand(discon)
 
-	help := model.NewAutocompleteData("help", "", "Display usage")
+	help := model.NewAutocompleteData("help", "", localizer.T("autocomplete.help"))
 	cal.AddCommand(help)
 	return cal
 }
 
 func (p *Plugin) executeCommandHelp(args *model.CommandArgs) string {
-	return "###### Mattermost Google Calendar Plugin - Slash Command Help\n" + strings.Replace(CommandHelp, "|", "`", -1)
+	localizer := p.getLocalizer(args.UserId)
+	return localizer.T("help.title") + strings.Replace(localizer.T("help"), "|", "`", -1)
 }
 
 func (p *Plugin) postCommandResponse(args *model.CommandArgs, text string) {
@@ -214,24 +235,20 @@ func (p *Plugin) executeCommandSummary(args *model.CommandArgs) string {
 	userID := args.UserId
 	cal, err := p.getCalendarService(userID)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+		if errors.Is(err, apperr.ErrNotConnected) {
 			// tell user to connect first
-			p.postCommandResponse(args, constant.ERR_CONNECT_FIRST)
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
 		}
 
 		p.API.LogError("Error execute command summary", "err", err.Error())
 		return ""
 	}
 
-	p.postCommandResponse(args, "Getting your summary...")
-	location, err := p.getPrimaryCalendarLocation(userID)
-	if err != nil {
-		return err.Error()
-	}
-
+	format := p.getTimeFormat(userID)
+	p.postCommandResponse(args, format.T("summary.loading"))
+	location := format.location
 	date := time.Now().In(location)
-	dateToDisplay := "Today"
-	titleToDisplay := "Today's"
+	titleToDisplay := format.T("summary.title.today")
 
 	// it means, specific date is given
 	if len(split) == 3 {
@@ -240,15 +257,13 @@ func (p *Plugin) executeCommandSummary(args *model.CommandArgs) string {
 			date = time.Now().In(location)
 		case "tmr":
 			date = time.Now().AddDate(0, 0, 1).In(location)
-			dateToDisplay = "Tomorrow"
-			titleToDisplay = "Tomorrow's"
+			titleToDisplay = format.T("summary.title.tomorrow")
 		default:
 			date, err = time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, split[2], location)
 			if err != nil {
-				return "Invalid date format, please use YYYY-MM-DD"
+				return format.T("summary.invalid_date")
 			}
-			dateToDisplay = date.Format(constant.DATE_FORMAT)
-			titleToDisplay = dateToDisplay
+			titleToDisplay = format.T("summary.title.date", format.Date(date))
 		}
 	}
 
@@ -258,22 +273,24 @@ func (p *Plugin) executeCommandSummary(args *model.CommandArgs) string {
 		SingleEvents(true).TimeMin(beginOfDay).TimeMax(endOfDay).OrderBy("startTime").Do()
 
 	if err != nil {
-		return "Error retrieiving events"
+		return format.T("events.fetch_failed")
 	}
 
 	if len(events.Items) == 0 {
-		if err := p.CreateBotDMPost(userID, "It seems that you don't have any events happening."); err != nil {
+		if err := p.CreateBotDMPost(userID, format.T("summary.empty")); err != nil {
 			p.API.LogError("Error creating bot post", "apErr", err.Error())
 			return "internal error"
 		}
 		return ""
 	}
 
-	text := fmt.Sprintf("#### %s Schedule:\n", titleToDisplay)
+	text := titleToDisplay
+	cards := make([]*model.SlackAttachment, 0, len(events.Items))
 	for _, item := range events.Items {
-		text += p.printEventSummary(userID, item)
+		text += p.printEventCardText(item, format, date)
+		cards = append(cards, p.newEventCard(userID, item, format, date))
 	}
-	if err := p.CreateBotDMPost(userID, text); err != nil {
+	if err := p.CreateBotDMPostWithAttachments(userID, text, cards); err != nil {
 		p.API.LogError("Error creating bot post", "apErr", err.Error())
 		return "internal error"
 	}
@@ -284,43 +301,167 @@ func (p *Plugin) executeCommandSettings(args *model.CommandArgs) string {
 	secret := p.getConfiguration().EncryptionSecret
 	user, err := p.services.userService.GetUserByID(args.UserId, secret)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+		if errors.Is(err, apperr.ErrNotConnected) {
 			// tell user to connect first
-			p.postCommandResponse(args, constant.ERR_CONNECT_FIRST)
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
 		}
 
 		p.API.LogError("Error execute command settings", "err", err.Error())
 		return ""
 	}
 
+	allDayReminderTime := user.Settings.AllDayReminderTime
+	if allDayReminderTime == "" {
+		allDayReminderTime = constant.DefaultUserSettings.AllDayReminderTime
+	}
+	var backupContact string
+	if user.Settings.OOOBackupContact != "" {
+		backupContact = "@" + user.Settings.OOOBackupContact
+	}
+	syncNotificationLayout := user.Settings.SyncNotificationLayout
+	if syncNotificationLayout == "" {
+		syncNotificationLayout = constant.DefaultUserSettings.SyncNotificationLayout
+	}
+	localizer := p.getLocalizer(args.UserId)
+	deliveryOptions := p.getDeliveryOptions(args.UserId, localizer)
+	deliveryHelpText := localizer.T("settings.delivery.help")
+
 	req := model.OpenDialogRequest{
 		TriggerId: args.TriggerId,
 		// URL:       fmt.Sprintf("%s/plugins/%s/settings", *p.API.GetConfig().ServiceSettings.SiteURL, manifest.ID),
 		URL: fmt.Sprintf("%s/plugins/%s/settings", p.getConfiguration().SiteUrl, manifest.ID),
 		Dialog: model.Dialog{
 			CallbackId: fmt.Sprintf("settings_cb_%s_%s", args.UserId, args.ChannelId),
-			Title:      "Settings",
+			Title:      localizer.T("settings.title"),
 			IconURL:    "https://img.icons8.com/color/48/000000/google-calendar--v2.png",
 			Elements: []model.DialogElement{
 				{
-					DisplayName: "Allow notifications",
+					DisplayName: localizer.T("settings.allow_notify"),
 					Name:        "AllowNotify",
 					Type:        "bool",
-					Placeholder: "Allow Notifications",
+					Placeholder: localizer.T("settings.allow_notify"),
 					Optional:    true,
 					Default:     HandleAllowNotiAtoBoolString(user.AllowNotify),
 				},
 				{
-					DisplayName: "Time notify before event",
+					DisplayName: localizer.T("settings.time_before"),
 					Name:        "TimeNotiBeforeEvent",
 					Type:        "text",
 					Placeholder: "",
 					MinLength:   1,
-					HelpText:    "This is the time before event to notify. It must be an positive integer in minute between 0 and 40320",
+					HelpText:    localizer.T("settings.time_before.help"),
 					Default:     strconv.Itoa(user.Settings.TimeNotiBeforeEvent),
 				},
+				{
+					DisplayName: localizer.T("settings.all_day_reminder"),
+					Name:        "AllDayReminderTime",
+					Type:        "text",
+					Placeholder: "HH:MM",
+					MinLength:   5,
+					MaxLength:   5,
+					HelpText:    localizer.T("settings.all_day_reminder.help"),
+					Default:     allDayReminderTime,
+				},
+				{
+					DisplayName: localizer.T("settings.notify_decline"),
+					Name:        "NotifyOnDecline",
+					Type:        "bool",
+					Placeholder: localizer.T("settings.notify_decline.placeholder"),
+					Optional:    true,
+					Default:     HandleBooString(user.Settings.NotifyOnDecline),
+				},
+				{
+					DisplayName: localizer.T("settings.ooo_reply"),
+					Name:        "OOOAutoReply",
+					Type:        "bool",
+					Placeholder: localizer.T("settings.ooo_reply.placeholder"),
+					Optional:    true,
+					Default:     HandleBooString(user.Settings.OOOAutoReply),
+				},
+				{
+					DisplayName: localizer.T("settings.ooo_backup"),
+					Name:        "OOOBackupContact",
+					Type:        "text",
+					Placeholder: "@username",
+					Optional:    true,
+					HelpText:    localizer.T("settings.ooo_backup.help"),
+					Default:     backupContact,
+				},
+				{
+					DisplayName: localizer.T("settings.layout"),
+					Name:        "SyncNotificationLayout",
+					Type:        "select",
+					HelpText:    localizer.T("settings.layout.help"),
+					Default:     syncNotificationLayout,
+					Options: []*model.PostActionOptions{
+						{Text: localizer.T("settings.layout.combined"), Value: constant.SYNC_LAYOUT_COMBINED},
+						{Text: localizer.T("settings.layout.threads"), Value: constant.SYNC_LAYOUT_THREADS},
+					},
+				},
+				{
+					DisplayName: localizer.T("settings.quiet_start"),
+					Name:        "QuietHoursStart",
+					Type:        "text",
+					Placeholder: "HH:MM",
+					Optional:    true,
+					HelpText:    localizer.T("settings.quiet_start.help"),
+					Default:     user.Settings.QuietHoursStart,
+				},
+				{
+					DisplayName: localizer.T("settings.quiet_end"),
+					Name:        "QuietHoursEnd",
+					Type:        "text",
+					Placeholder: "HH:MM",
+					Optional:    true,
+					HelpText:    localizer.T("settings.quiet_end.help"),
+					Default:     user.Settings.QuietHoursEnd,
+				},
+				{
+					DisplayName: localizer.T("settings.digest"),
+					Name:        "DigestMode",
+					Type:        "select",
+					HelpText:    localizer.T("settings.digest.help"),
+					Default:     digestMode(user.Settings),
+					Options: []*model.PostActionOptions{
+						{Text: localizer.T("settings.digest.off"), Value: constant.DIGEST_MODE_OFF},
+						{Text: localizer.T("settings.digest.hourly"), Value: constant.DIGEST_MODE_HOURLY},
+						{Text: localizer.T("settings.digest.twice_daily"), Value: constant.DIGEST_MODE_TWICE_DAILY},
+					},
+				},
+				{
+					DisplayName: localizer.T("settings.invite_delivery"),
+					Name:        "InviteDelivery",
+					Type:        "select",
+					HelpText:    deliveryHelpText,
+					Default:     deliveryDefault(deliveryOptions, notificationDelivery(user.Settings, constant.NOTIFICATION_INVITES)),
+					Options:     deliveryOptions,
+				},
+				{
+					DisplayName: localizer.T("settings.update_delivery"),
+					Name:        "UpdateDelivery",
+					Type:        "select",
+					HelpText:    deliveryHelpText,
+					Default:     deliveryDefault(deliveryOptions, notificationDelivery(user.Settings, constant.NOTIFICATION_UPDATES)),
+					Options:     deliveryOptions,
+				},
+				{
+					DisplayName: localizer.T("settings.reminder_delivery"),
+					Name:        "ReminderDelivery",
+					Type:        "select",
+					HelpText:    deliveryHelpText,
+					Default:     deliveryDefault(deliveryOptions, notificationDelivery(user.Settings, constant.NOTIFICATION_REMINDERS)),
+					Options:     deliveryOptions,
+				},
+				{
+					DisplayName: localizer.T("settings.digest_delivery"),
+					Name:        "DigestDelivery",
+					Type:        "select",
+					HelpText:    deliveryHelpText,
+					Default:     deliveryDefault(deliveryOptions, notificationDelivery(user.Settings, constant.NOTIFICATION_DIGESTS)),
+					Options:     deliveryOptions,
+				},
 			},
-			SubmitLabel: "Save",
+			SubmitLabel: localizer.T("dialog.save"),
 			// NotifyOnCancel: true,
 		},
 	}
@@ -335,58 +476,201 @@ func (p *Plugin) executeCommandSettings(args *model.CommandArgs) string {
 func (p *Plugin) executeCommandNext(args *model.CommandArgs) string {
 	cal, err := p.getCalendarService(args.UserId)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
+		if errors.Is(err, apperr.ErrNotConnected) {
 			// tell user to connect first
-			p.postCommandResponse(args, constant.ERR_CONNECT_FIRST)
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
 		}
 
 		p.API.LogError("Error execute command next", "err", err.Error())
 		return ""
 	}
 
-	p.postCommandResponse(args, "Getting your next event...")
 	userID := args.UserId
-	location, err := p.getPrimaryCalendarLocation(userID)
-	if err != nil {
-		return err.Error()
-	}
-
+	format := p.getTimeFormat(userID)
+	p.postCommandResponse(args, format.T("next.loading"))
+	location := format.location
 	date := time.Now().In(location)
 	start := date.Format(time.RFC3339)
 	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 0, location).Format(time.RFC3339)
 	events, err := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID).ShowDeleted(false).
 		SingleEvents(true).MaxResults(1).TimeMin(start).TimeMax(endOfDay).OrderBy("startTime").Do()
 	if err != nil {
-		return "Error retrieiving events"
+		return format.T("events.fetch_failed")
 	}
 	if len(events.Items) == 0 {
-		p.CreateBotDMPost(userID, "It seems that you don't have any events happening today.")
+		p.CreateBotDMPost(userID, format.T("next.empty"))
 		return ""
 	}
 
-	text := "#### Next Event:\n"
-	text += p.printEventSummary(userID, events.Items[0])
-	if appErr := p.CreateBotDMPost(userID, text); appErr != nil {
+	text := format.T("next.title") + p.printEventCardText(events.Items[0], format, date)
+	card := p.newEventCard(userID, events.Items[0], format, date)
+	if appErr := p.CreateBotDMPostWithAttachments(userID, text, []*model.SlackAttachment{card}); appErr != nil {
 		p.API.LogError("Error creating bot post", "apErr", appErr.Error())
 		return appErr.Error()
 	}
 	return ""
 }
 
+func (p *Plugin) executeCommandAgenda(args *model.CommandArgs) string {
+	split := strings.Fields(args.Command)
+	userID := args.UserId
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
+		}
+
+		p.API.LogError("Error execute command agenda", "err", err.Error())
+		return ""
+	}
+
+	format := p.getTimeFormat(userID)
+	location := format.location
+
+	// /calendar agenda [range] [compact]
+	rangeArgs := split[2:]
+	compact := false
+	if len(rangeArgs) > 0 && strings.EqualFold(rangeArgs[len(rangeArgs)-1], "compact") {
+		compact = true
+		rangeArgs = rangeArgs[:len(rangeArgs)-1]
+	}
+	start, end, title, err := parseAgendaRange(strings.Join(rangeArgs, " "), time.Now().In(location), format)
+	if err != nil {
+		return err.Error()
+	}
+
+	p.postCommandResponse(args, format.T("agenda.loading"))
+	events, err := p.listEventsInRange(cal, start, end)
+	if err != nil {
+		return format.T("events.fetch_failed")
+	}
+
+	if len(events) == 0 {
+		if err := p.CreateBotDMPost(userID, format.T("summary.empty")); err != nil {
+			p.API.LogError("Error creating bot post", "apErr", err.Error())
+			return "internal error"
+		}
+		return ""
+	}
+
+	for _, text := range p.renderAgenda(title, groupEventsByDay(events, location, start, end), format, compact) {
+		if err := p.CreateBotDMPost(userID, text); err != nil {
+			p.API.LogError("Error creating bot post", "apErr", err.Error())
+			return "internal error"
+		}
+	}
+	return ""
+}
+
+func (p *Plugin) executeCommandConflicts(args *model.CommandArgs) string {
+	split := strings.Fields(args.Command)
+	userID := args.UserId
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
+		}
+
+		p.API.LogError("Error execute command conflicts", "err", err.Error())
+		return ""
+	}
+
+	format := p.getTimeFormat(userID)
+	location := format.location
+
+	start, end, title, err := parseAgendaRange(strings.Join(split[2:], " "), time.Now().In(location), format)
+	if err != nil {
+		return err.Error()
+	}
+
+	p.postCommandResponse(args, format.T("conflicts.loading"))
+	events, err := p.listEventsInRange(cal, start, end)
+	if err != nil {
+		return format.T("events.fetch_failed")
+	}
+
+	conflicts := p.findConflicts(events, location)
+	if len(conflicts) == 0 {
+		if err := p.CreateBotDMPost(userID, format.T("conflicts.empty")); err != nil {
+			p.API.LogError("Error creating bot post", "apErr", err.Error())
+			return "internal error"
+		}
+		return ""
+	}
+
+	text := format.T("conflicts.title", title)
+	text += p.printConflicts(conflicts, format)
+	if err := p.CreateBotDMPost(userID, text); err != nil {
+		p.API.LogError("Error creating bot post", "apErr", err.Error())
+		return "internal error"
+	}
+	return ""
+}
+
+func (p *Plugin) executeCommandSearch(args *model.CommandArgs) string {
+	split := strings.Fields(args.Command)
+	userID := args.UserId
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
+		}
+
+		p.API.LogError("Error execute command search", "err", err.Error())
+		return ""
+	}
+
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		return err.Error()
+	}
+
+	localizer := p.getLocalizer(userID)
+	search, err := p.parseEventSearch(split[2:], location, localizer)
+	if err != nil {
+		return err.Error()
+	}
+
+	p.postCommandResponse(args, localizer.T("search.loading"))
+	results, err := p.searchEvents(userID, cal, search, location)
+	if err != nil {
+		p.API.LogError("Error searching events", "err", err.Error())
+		return localizer.T("events.fetch_failed")
+	}
+
+	if len(results) == 0 {
+		if err := p.CreateBotDMPost(userID, localizer.T("search.empty")); err != nil {
+			p.API.LogError("Error creating bot post", "apErr", err.Error())
+			return "internal error"
+		}
+		return ""
+	}
+
+	if err := p.postSearchResults(userID, search, results, 0); err != nil {
+		p.API.LogError("Error creating bot post", "apErr", err.Error())
+		return "internal error"
+	}
+	return ""
+}
+
 func (p *Plugin) executeCommandDisconnect(args *model.CommandArgs) string {
 	if err := p.ValidateCalendarConnection(args); err != nil {
 		return ""
 	}
 
 	// confirmation
+	localizer := p.getLocalizer(args.UserId)
 	req := model.OpenDialogRequest{
 		TriggerId: args.TriggerId,
 		URL:       fmt.Sprintf("%s/plugins/%s/disconnect", p.getConfiguration().SiteUrl, manifest.ID),
 		Dialog: model.Dialog{
 			CallbackId:  fmt.Sprintf("disconnect_cb_%s_%s", args.UserId, args.ChannelId),
-			Title:       "Are you sure to disconnect?",
+			Title:       localizer.T("disconnect.confirm.title"),
 			IconURL:     "https://img.icons8.com/color/48/000000/google-calendar--v2.png",
-			SubmitLabel: "Confirm",
+			SubmitLabel: localizer.T("disconnect.confirm"),
 		},
 	}
 	if err := p.API.OpenInteractiveDialog(req); err != nil {
@@ -399,10 +683,11 @@ func (p *Plugin) executeCommandDisconnect(args *model.CommandArgs) string {
 
 func (p *Plugin) executeCommandCreate(args *model.CommandArgs) string {
 	userID := args.UserId
+	localizer := p.getLocalizer(userID)
 	hasErr := false
 	defer func() {
 		if hasErr {
-			p.postCommandResponse(args, fmt.Sprintf("latest command is `%s`", args.Command))
+			p.postCommandResponse(args, localizer.T("event.create.command", args.Command))
 		}
 	}()
 
@@ -419,43 +704,43 @@ func (p *Plugin) executeCommandCreate(args *model.CommandArgs) string {
 
 	split := strings.Fields(args.Command)
 	if len(split) < 4 {
-		return "Missing start date-time"
+		return localizer.T("event.create.missing_start")
 	}
 
 	// /calendar => split[0]
 	// command name => split[1]
 	title := split[2]
 	if title == "" {
-		return "Missing title"
+		return localizer.T("event.create.missing_title")
 	}
 
 	//validate single quote in summary name
 	if !strings.HasPrefix(title, "'") && !strings.HasSuffix(title, "'") {
-		p.postCommandResponse(args, fmt.Sprintf("your latest command => [%s]", args.Command))
-		return "Title must be in single quote"
+		p.postCommandResponse(args, localizer.T("event.create.command", args.Command))
+		return localizer.T("event.create.title_quotes")
 	}
 
 	start := split[3]
 	startTime, err := time.ParseInLocation(constant.CUSTOM_FORMAT, start, location)
 	if err != nil {
 		hasErr = true
-		return fmt.Sprintf("Invalid format of start date-time: %v", err)
+		return localizer.T("event.create.invalid_start", err)
 	}
 
 	if len(split) < 5 {
 		hasErr = true
-		return "Missing end date-time"
+		return localizer.T("event.create.missing_end")
 	}
 	endTime, err := time.ParseInLocation(constant.CUSTOM_FORMAT, split[4], location)
 	if err != nil {
 		hasErr = true
-		return fmt.Sprintf("Invalid format of end date-time: %v", err)
+		return localizer.T("event.create.invalid_end", err)
 	}
 
 	// validate time
 	if startTime.After(endTime) {
 		hasErr = true
-		return "Start time must be before end time"
+		return localizer.T("event.edit.start_after_end")
 	}
 
 	// organizer is the first attendee
@@ -487,34 +772,22 @@ func (p *Plugin) executeCommandCreate(args *model.CommandArgs) string {
 				continue
 			}
 
-			var email string
-			// come from @mention in mattermost
-			if strings.HasPrefix(member, "@") {
-				username := strings.Replace(member, "@", "", -1)
-				user, err := p.API.GetUserByUsername(username)
-				if err != nil {
-					hasErr = true
-					return fmt.Sprintf("username %s not found\n", username)
-				}
-				email = user.Email
-			} else {
-				// come from email address
-				rx, err := regexp.Compile(constant.EMAIL_REGEX)
-				if err != nil {
-					hasErr = true
-					return err.Error()
-				}
-				if !rx.MatchString(member) {
-					hasErr = true
-					return fmt.Sprintf("invalid email address: %s", member)
-				}
-				email = member
+			email, err := p.services.attendeeService.EmailOf(member)
+			if err != nil {
+				hasErr = true
+				return err.Error()
 			}
 			attendees = append(attendees, &calendar.EventAttendee{
 				Email: email,
 			})
 		}
 	}
+	force := false
+	for _, arg := range split[5:] {
+		if arg == constant.FORCE_FLAG {
+			force = true
+		}
+	}
 	summary := title[1 : len(title)-1]
 	newEvent := calendar.Event{
 		Summary:   summary,
@@ -531,25 +804,164 @@ func (p *Plugin) executeCommandCreate(args *model.CommandArgs) string {
 			},
 		},
 	}
+	if !force {
+		events, err := p.listEventsInRange(cal, startTime, endTime)
+		if err != nil {
+			hasErr = true
+			return localizer.T("event.create.conflict_check", err)
+		}
+		// the user organizes the event, the warning doesn't offer to decline it
+		proposed := newEvent
+		proposed.Organizer = &calendar.EventOrganizer{Email: cal.email, Self: true}
+		if conflicts := p.findOverlappingEvents(&proposed, events, location); len(conflicts) > 0 {
+			format := p.getTimeFormat(userID)
+			return format.T("event.create.conflicts") + p.printConflictWarning(&proposed, conflicts, format) +
+				format.T("event.create.force", constant.FORCE_FLAG)
+		}
+	}
+
 	createdEvent, err := cal.service.Events.Insert(constant.PRIMARY_CALENDAR_ID, &newEvent).ConferenceDataVersion(1).SendUpdates("all").Do()
 	if err != nil {
 		hasErr = true
-		return fmt.Sprintf("Failed to create calendar event. Error: %v", err)
+		return localizer.T("event.create.failed", err)
 	}
-	if err := p.CreateBotDMPost(args.UserId, fmt.Sprintf("Success! Event _[%s](%s)_ on %v has been created.",
-		createdEvent.Summary, createdEvent.HtmlLink, startTime.Format(constant.DATE_FORMAT))); err != nil {
+	format := p.getTimeFormat(args.UserId)
+	if err := p.CreateBotDMPost(args.UserId, format.T("event.create.success", createdEvent.Summary, createdEvent.HtmlLink,
+		format.Date(startTime))); err != nil {
 		p.API.LogError("Error creating bot post", "apErr", err.Error())
 	}
 
 	return ""
 }
 
+func (p *Plugin) executeCommandEdit(args *model.CommandArgs) string {
+	localizer := p.getLocalizer(args.UserId)
+	split := strings.Fields(args.Command)
+	if len(split) < 3 {
+		return localizer.T("event.missing")
+	}
+	eventID := split[2]
+
+	userID := args.UserId
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
+		}
+
+		p.API.LogError("Error execute command edit", "err", err.Error())
+		return ""
+	}
+
+	location, err := p.getPrimaryCalendarLocation(userID)
+	if err != nil {
+		return err.Error()
+	}
+
+	event, err := cal.service.Events.Get(constant.PRIMARY_CALENDAR_ID, eventID).Do()
+	if err != nil {
+		return localizer.T("event.not_found", eventID)
+	}
+	if event.Organizer == nil || !event.Organizer.Self {
+		return p.userMessage(args.UserId, apperr.NotOrganizer("edit"))
+	}
+
+	var guests []string
+	for _, attendee := range event.Attendees {
+		if attendee.Self {
+			continue
+		}
+		guests = append(guests, attendee.Email)
+	}
+
+	req := model.OpenDialogRequest{
+		TriggerId: args.TriggerId,
+		URL:       fmt.Sprintf("%s/plugins/%s/edit", p.getConfiguration().SiteUrl, manifest.ID),
+		Dialog: model.Dialog{
+			CallbackId: fmt.Sprintf("edit_event_cb_%s_%s", args.UserId, args.ChannelId),
+			Title:      localizer.T("event.edit.title"),
+			IconURL:    "https://img.icons8.com/color/48/000000/google-calendar--v2.png",
+			Elements: []model.DialogElement{
+				{
+					DisplayName: localizer.T("event.edit.field.title"),
+					Name:        localizer.T("event.edit.field.title"),
+					Type:        "text",
+					Placeholder: localizer.T("event.edit.field.title.placeholder"),
+					MinLength:   1,
+					Default:     event.Summary,
+				},
+				{
+					DisplayName: localizer.T("event.edit.field.start"),
+					Name:        "StartDateTime",
+					Type:        "text",
+					Placeholder: "YYYY-MM-DD@HH:MM",
+					HelpText:    localizer.T("event.edit.field.date.help"),
+					MinLength:   1,
+					Default:     formatEventDateTimeInput(event.Start, location, false),
+				},
+				{
+					DisplayName: localizer.T("event.edit.field.end"),
+					Name:        "EndDateTime",
+					Type:        "text",
+					Placeholder: "YYYY-MM-DD@HH:MM",
+					HelpText:    localizer.T("event.edit.field.date.help"),
+					MinLength:   1,
+					Default:     formatEventDateTimeInput(event.End, location, true),
+				},
+				{
+					DisplayName: localizer.T("event.edit.field.location"),
+					Name:        "Location",
+					Type:        "text",
+					Optional:    true,
+					Default:     event.Location,
+				},
+				{
+					DisplayName: localizer.T("event.edit.field.description"),
+					Name:        "Description",
+					Type:        "textarea",
+					Optional:    true,
+					MaxLength:   3000,
+					Default:     event.Description,
+				},
+				{
+					DisplayName: localizer.T("event.edit.field.attendees"),
+					Name:        "Attendees",
+					Type:        "text",
+					Optional:    true,
+					HelpText:    localizer.T("event.edit.field.attendees.help"),
+					Default:     strings.Join(guests, " "),
+				},
+				{
+					DisplayName: localizer.T("event.edit.field.send_updates"),
+					Name:        "SendUpdates",
+					Type:        "select",
+					Default:     constant.SEND_UPDATES_ALL,
+					Options: []*model.PostActionOptions{
+						{Text: localizer.T("event.edit.send_updates.all"), Value: constant.SEND_UPDATES_ALL},
+						{Text: localizer.T("event.edit.send_updates.external"), Value: constant.SEND_UPDATES_EXTERNAL_ONLY},
+						{Text: localizer.T("event.edit.send_updates.none"), Value: constant.SEND_UPDATES_NONE},
+					},
+				},
+			},
+			SubmitLabel: localizer.T("dialog.save"),
+			State:       event.Id,
+		},
+	}
+	if err := p.API.OpenInteractiveDialog(req); err != nil {
+		errorMessage := "Failed to open Interactive Dialog"
+		p.API.LogError(errorMessage, "err", err.Error())
+		return err.Error()
+	}
+	return ""
+}
+
 func (p *Plugin) ValidateCalendarConnection(args *model.CommandArgs) error {
 	secret := p.getConfiguration().EncryptionSecret
 	_, err := p.services.userService.GetUserByID(args.UserId, secret)
 	if err != nil {
-		if err.Error() == constant.INTERNAL_ERR_USER_NOT_FOUND {
-			p.postCommandResponse(args, constant.ERR_CONNECT_FIRST)
+		if errors.Is(err, apperr.ErrNotConnected) {
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
 		}
 
 		p.API.LogError("Error execute command", "err", err.Error())
@@ -557,3 +969,96 @@ func (p *Plugin) ValidateCalendarConnection(args *model.CommandArgs) error {
 	}
 	return nil
 }
+
+func (p *Plugin) executeCommandResponses(args *model.CommandArgs) string {
+	localizer := p.getLocalizer(args.UserId)
+	split := strings.Fields(args.Command)
+	if len(split) < 3 {
+		return localizer.T("event.missing")
+	}
+	eventID := split[2]
+
+	userID := args.UserId
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
+		}
+
+		p.API.LogError("Error execute command responses", "err", err.Error())
+		return ""
+	}
+
+	event, err := cal.service.Events.Get(constant.PRIMARY_CALENDAR_ID, eventID).Do()
+	if err != nil {
+		return localizer.T("event.not_found", eventID)
+	}
+	if event.Organizer == nil || !event.Organizer.Self {
+		return p.userMessage(args.UserId, apperr.NotOrganizer("responses"))
+	}
+	if len(event.Attendees) == 0 {
+		return localizer.T("responses.no_guests")
+	}
+
+	if appErr := p.postResponses(userID, event); appErr != nil {
+		p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+		return localizer.T("responses.failed")
+	}
+	return ""
+}
+
+func (p *Plugin) executeCommandTeam(args *model.CommandArgs) string {
+	split := strings.Fields(args.Command)
+	var target string
+	if len(split) > 2 {
+		target = split[2]
+	}
+
+	userID := args.UserId
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			// tell user to connect first
+			p.postCommandResponse(args, p.userMessage(args.UserId, err))
+		}
+
+		p.API.LogError("Error execute command team", "err", err.Error())
+		return ""
+	}
+
+	if target == "subscribe" || target == "unsubscribe" {
+		text, err := p.subscribeTeamDigest(userID, args.ChannelId, target == "subscribe")
+		if err != nil {
+			p.API.LogError("Error subscribing team digest", "err", err.Error())
+			return err.Error()
+		}
+		return text
+	}
+
+	format := p.getTimeFormat(userID)
+	location := format.location
+	viewer, appErr := p.API.GetUser(userID)
+	if appErr != nil {
+		return appErr.Error()
+	}
+
+	users, title, err := p.getTeamUsers(args, target, format.localizer)
+	if err != nil {
+		return err.Error()
+	}
+	members := p.getTeamMembers(users)
+	if len(members) == 0 {
+		return format.T("team.nobody", title)
+	}
+
+	now := time.Now().In(location)
+	freeBusy := p.getFreeBusy(cal, members, now, dateOf(now, location).AddDate(0, 0, 1))
+	var statuses []memberStatus
+	for _, member := range members {
+		statuses = append(statuses, p.getMemberStatus(member, freeBusy[strings.ToLower(member.user.Email)], viewer.Email, now, location))
+	}
+
+	p.postCommandResponse(args, renderTeamBoard(title, statuses, now, format))
+	return ""
+}
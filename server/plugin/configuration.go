@@ -1,9 +1,11 @@
 package plugin
 
 import (
+	"encoding/json"
 	"reflect"
 
 	"github.com/pkg/errors"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/templates"
 )
 
 // configuration captures the plugin's external configuration as exposed in the Mattermost server
@@ -27,6 +29,14 @@ type configuration struct {
 	DbPassword           string
 	DbName               string
 	EncryptionSecret     string
+
+	// MessagePreset names the builtin templates of the bot posts, MessageTemplates overrides some of
+	// them with a JSON object mapping the name of a template to its text
+	MessagePreset    string
+	MessageTemplates string
+
+	// templates are parsed from MessagePreset and MessageTemplates
+	templates *templates.Set
 }
 
 // Clone shallow copies the configuration. Your implementation may require a deep copy if
@@ -85,6 +95,19 @@ func (p *Plugin) OnConfigurationChange() error {
 	if err := p.API.LoadPluginConfiguration(configuration); err != nil {
 		return errors.Wrap(err, "failed to load plugin configuration")
 	}
+
+	var overrides map[string]string
+	if configuration.MessageTemplates != "" {
+		if err := json.Unmarshal([]byte(configuration.MessageTemplates), &overrides); err != nil {
+			return errors.Wrap(err, "message templates must be a JSON object mapping template names to templates")
+		}
+	}
+	set, err := templates.New(configuration.MessagePreset, overrides)
+	if err != nil {
+		return errors.Wrap(err, "invalid message templates")
+	}
+	configuration.templates = set
+
 	p.setConfiguration(configuration)
 	return nil
 }
@@ -0,0 +1,123 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"google.golang.org/api/calendar/v3"
+)
+
+// eventConflict is a pair of events happening at the same time, first starts before second
+type eventConflict struct {
+	first  *calendar.Event
+	second *calendar.Event
+}
+
+// isBusyEvent tells whether the event blocks the user's time. Cancelled, free and all-day events
+// do not, neither do events the user has not accepted or tentatively accepted
+func (p *Plugin) isBusyEvent(event *calendar.Event) bool {
+	if !p.canConflict(event) {
+		return false
+	}
+	self := p.retrieveMyselfForEvent(event)
+	if self == nil {
+		// events without guests are the user's own events
+		return true
+	}
+	return self.ResponseStatus == constant.EV_STATUS_ACCEPTED || self.ResponseStatus == constant.EV_STATUS_TENTATIVE
+}
+
+// canConflict tells whether the event takes some time, whatever the user answered
+func (p *Plugin) canConflict(event *calendar.Event) bool {
+	if p.isEventDeleted(event) || event.Transparency == "transparent" {
+		return false
+	}
+	return event.Start != nil && event.Start.DateTime != ""
+}
+
+// findOverlappingEvents returns the busy events happening at the same time as the given event
+func (p *Plugin) findOverlappingEvents(event *calendar.Event, events []*calendar.Event, location *time.Location) []*calendar.Event {
+	if !p.canConflict(event) {
+		return nil
+	}
+
+	et := newEventTime(event, location)
+	var overlaps []*calendar.Event
+	for _, other := range events {
+		if other.Id == event.Id || !p.isBusyEvent(other) {
+			continue
+		}
+		if et.Overlaps(newEventTime(other, location)) {
+			overlaps = append(overlaps, other)
+		}
+	}
+	return overlaps
+}
+
+// findConflicts returns every pair of busy events overlapping each other, in chronological order
+func (p *Plugin) findConflicts(events []*calendar.Event, location *time.Location) []eventConflict {
+	var busy []*calendar.Event
+	for _, event := range events {
+		if p.isBusyEvent(event) {
+			busy = append(busy, event)
+		}
+	}
+	sortEvents(busy, location)
+
+	var conflicts []eventConflict
+	for i, first := range busy {
+		firstTime := newEventTime(first, location)
+		for _, second := range busy[i+1:] {
+			secondTime := newEventTime(second, location)
+			// events are sorted by start, nothing after this one can overlap
+			if !secondTime.Start.Before(firstTime.End) {
+				break
+			}
+			if first.Id != second.Id && firstTime.Overlaps(secondTime) {
+				conflicts = append(conflicts, eventConflict{first: first, second: second})
+			}
+		}
+	}
+	return conflicts
+}
+
+// printConflictWarning warns about the events overlapping the given event and offers to decline it
+// or to propose a new time from Google Calendar
+func (p *Plugin) printConflictWarning(event *calendar.Event, conflicts []*calendar.Event, format timeFormat) string {
+	text := format.T("conflicts.warning")
+	for _, conflict := range conflicts {
+		text += fmt.Sprintf("- [%s](%s) %s\n", conflict.Summary, conflict.HtmlLink,
+			printEventWhen(newEventTime(conflict, format.location), format, time.Time{}))
+	}
+
+	if event.Organizer != nil && event.Organizer.Self {
+		return text
+	}
+	url := fmt.Sprintf("%s/plugins/%s/handleresponse?evtid=%s&response=%s",
+		p.getConfiguration().SiteUrl, manifest.ID, event.Id, constant.EV_STATUS_DECLINED)
+	text += format.T("conflicts.double_booked", url, event.HtmlLink)
+	return text
+}
+
+// printConflicts lists the conflicting pairs of events
+func (p *Plugin) printConflicts(conflicts []eventConflict, format timeFormat) string {
+	var text string
+	for _, conflict := range conflicts {
+		text += format.T("conflicts.pair",
+			conflict.first.Summary, conflict.first.HtmlLink,
+			printEventWhen(newEventTime(conflict.first, format.location), format, time.Time{}),
+			conflict.second.Summary, conflict.second.HtmlLink,
+			printEventWhen(newEventTime(conflict.second, format.location), format, time.Time{}))
+
+		for _, event := range []*calendar.Event{conflict.first, conflict.second} {
+			if event.Organizer != nil && event.Organizer.Self {
+				continue
+			}
+			url := fmt.Sprintf("%s/plugins/%s/handleresponse?evtid=%s&response=%s",
+				p.getConfiguration().SiteUrl, manifest.ID, event.Id, constant.EV_STATUS_DECLINED)
+			text += format.T("conflicts.decline", event.Summary, url, event.HtmlLink)
+		}
+	}
+	return text
+}
@@ -10,43 +10,30 @@ import (
 
 func (p *Plugin) notifyCronJob() error {
 	cron := cron.New()
+	p.cron = cron
 	_, err := cron.AddFunc("@every 1m", func() {
-		page := 1
-		limit := 100
-		secret := p.getConfiguration().EncryptionSecret
-		for {
-
-			result, err := p.services.userService.List(secret, models.ListUsersOption{
-				Page:        page,
-				Limit:       limit,
-				AllowNotify: constant.ALLOW_NOTIFY,
-			})
-			if err != nil {
-				p.API.LogError("Error getting users", "err", err.Error())
-				return
-			}
-			if len(result.Users) == 0 {
-				break
-			}
-
-			var wg sync.WaitGroup
-			wg.Add(len(result.Users))
-			maxGoroutines := 20
-			queues := make(chan struct{}, maxGoroutines)
-			for _, user := range result.Users {
-				queues <- struct{}{}
-				go func(user models.UserDataDto) {
-					defer func() {
-						wg.Done()
-						<-queues
-					}()
-
-					p.notifyUser(user)
-				}(user)
-			}
-			wg.Wait()
-			page++
-		}
+		p.forEachUser("reminders", constant.ALLOW_NOTIFY, p.notifyUser)
+	})
+	if err != nil {
+		p.API.LogError("Error starting cron job", "err", err)
+		return err
+	}
+	_, err = cron.AddFunc("@every 1m", func() {
+		p.forEachUser("team_digests", "", p.postTeamDigests)
+	})
+	if err != nil {
+		p.API.LogError("Error starting cron job", "err", err)
+		return err
+	}
+	_, err = cron.AddFunc("@every 1m", func() {
+		p.forEachUser("notification_digests", constant.ALLOW_NOTIFY, p.postNotificationDigest)
+	})
+	if err != nil {
+		p.API.LogError("Error starting cron job", "err", err)
+		return err
+	}
+	_, err = cron.AddFunc("@daily", func() {
+		p.forEachUser("event_threads", "", p.pruneUserEventThreads)
 	})
 	if err != nil {
 		p.API.LogError("Error starting cron job", "err", err)
@@ -56,6 +43,55 @@ func (p *Plugin) notifyCronJob() error {
 	return nil
 }
 
+// forEachUser runs fn for every connected user, filtered by allowNotify when it is set. The users
+// waiting or being processed are counted in the depth of the queue
+func (p *Plugin) forEachUser(queue string, allowNotify string, fn func(models.UserDataDto)) {
+	page := 1
+	limit := 100
+	secret := p.getConfiguration().EncryptionSecret
+	for p.ctx.Err() == nil {
+		result, err := p.services.userService.List(secret, models.ListUsersOption{
+			Page:        page,
+			Limit:       limit,
+			AllowNotify: allowNotify,
+		})
+		if err != nil {
+			p.API.LogError("Error getting users", "err", err.Error())
+			return
+		}
+		if len(result.Users) == 0 {
+			break
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(len(result.Users))
+		maxGoroutines := 20
+		queues := make(chan struct{}, maxGoroutines)
+		p.metrics.queueDepth.Add(float64(len(result.Users)), queue)
+		for _, user := range result.Users {
+			queues <- struct{}{}
+			// the plugin is deactivating, the users left are skipped
+			if p.ctx.Err() != nil {
+				wg.Done()
+				<-queues
+				p.metrics.queueDepth.Add(-1, queue)
+				continue
+			}
+			go func(user models.UserDataDto) {
+				defer func() {
+					wg.Done()
+					<-queues
+					p.metrics.queueDepth.Add(-1, queue)
+				}()
+
+				fn(user)
+			}(user)
+		}
+		wg.Wait()
+		page++
+	}
+}
+
 func (p *Plugin) notifyUser(user models.UserDataDto) {
 	if user.AllowNotify != constant.ALLOW_NOTIFY {
 		return
@@ -63,4 +99,14 @@ func (p *Plugin) notifyUser(user models.UserDataDto) {
 	if err := p.remindUserV2(user); err != nil {
 		p.API.LogError("Error reminding user", "err", err)
 	}
+	if err := p.remindSnoozed(user); err != nil {
+		p.API.LogError("Error sending snoozed reminders", "err", err)
+	}
+}
+
+// pruneUserEventThreads forgets the threads of the events of the user that are over
+func (p *Plugin) pruneUserEventThreads(user models.UserDataDto) {
+	if err := p.pruneEventThreads(user.UserID); err != nil {
+		p.API.LogError("Error pruning event threads", "err", err.Error(), "userID", user.UserID)
+	}
 }
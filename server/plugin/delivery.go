@@ -0,0 +1,108 @@
+package plugin
+
+import (
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+)
+
+// notificationDelivery returns where the user gets the notifications of a type: DELIVERY_DM,
+// DELIVERY_EPHEMERAL or the ID of one of their private channels
+func notificationDelivery(settings models.UserSettings, notification string) string {
+	var delivery string
+	switch notification {
+	case constant.NOTIFICATION_INVITES:
+		delivery = settings.InviteDelivery
+	case constant.NOTIFICATION_UPDATES:
+		delivery = settings.UpdateDelivery
+	case constant.NOTIFICATION_REMINDERS:
+		delivery = settings.ReminderDelivery
+	case constant.NOTIFICATION_DIGESTS:
+		delivery = settings.DigestDelivery
+	}
+	if delivery == "" {
+		return constant.DELIVERY_DM
+	}
+	return delivery
+}
+
+// getDeliveryChannel returns the channel of the posts delivered to the user. Ephemeral posts are
+// shown in the bot DM channel, and so are the posts of a channel the user or the bot can't use
+// anymore
+func (p *Plugin) getDeliveryChannel(userID, delivery string) (string, *model.AppError) {
+	if delivery != constant.DELIVERY_DM && delivery != constant.DELIVERY_EPHEMERAL {
+		_, err := p.validateDeliveryChannel(userID, "", delivery, i18n.NewLocalizer(i18n.DefaultLocale))
+		if err == nil {
+			return delivery, nil
+		}
+		p.API.LogWarn("Can't deliver to the chosen channel, using the bot DM channel", "user_id", userID, "channel_id", delivery, "err", err.Error())
+	}
+
+	channel, appErr := p.API.GetDirectChannel(userID, p.botID)
+	if appErr != nil {
+		p.API.LogError("Couldn't get bot's DM channel", "user_id", userID)
+		return "", appErr
+	}
+	return channel.Id, nil
+}
+
+// validateDeliveryChannel checks that the channel is a private channel created by the user, that
+// they are still a member of it and that the bot can post there. The errors are
+// apperr.ValidationError of field
+func (p *Plugin) validateDeliveryChannel(userID, field, channelID string, localizer *i18n.Localizer) (*model.Channel, error) {
+	channel, appErr := p.API.GetChannel(channelID)
+	if appErr != nil || channel.DeleteAt != 0 || channel.Type != model.CHANNEL_PRIVATE || channel.CreatorId != userID {
+		return nil, apperr.Validation(field, localizer.T("settings.invalid.delivery_channel"))
+	}
+	if _, appErr := p.API.GetChannelMember(channelID, userID); appErr != nil {
+		return nil, apperr.Validation(field, localizer.T("settings.invalid.delivery_channel"))
+	}
+	if !p.API.HasPermissionToChannel(p.botID, channelID, model.PERMISSION_CREATE_POST) {
+		return nil, apperr.Validation(field, localizer.T("settings.invalid.delivery_bot", channel.Name))
+	}
+	return channel, nil
+}
+
+// getDeliveryOptions lists where the notifications can be delivered in the settings dialog: the
+// bot DM channel, ephemeral posts and the private channels the user created
+func (p *Plugin) getDeliveryOptions(userID string, localizer *i18n.Localizer) []*model.PostActionOptions {
+	options := []*model.PostActionOptions{
+		{Text: localizer.T("settings.delivery.dm"), Value: constant.DELIVERY_DM},
+		{Text: localizer.T("settings.delivery.ephemeral"), Value: constant.DELIVERY_EPHEMERAL},
+	}
+
+	teams, appErr := p.API.GetTeamsForUser(userID)
+	if appErr != nil {
+		p.API.LogError("Error getting teams of user", "err", appErr.Error())
+		return options
+	}
+	for _, team := range teams {
+		channels, appErr := p.API.GetChannelsForTeamForUser(team.Id, userID, false)
+		if appErr != nil {
+			p.API.LogError("Error getting channels of user", "err", appErr.Error(), "teamID", team.Id)
+			continue
+		}
+		for _, channel := range channels {
+			if channel.Type == model.CHANNEL_PRIVATE && channel.CreatorId == userID {
+				options = append(options, &model.PostActionOptions{
+					Text:  localizer.T("settings.delivery.channel", channel.Name, team.DisplayName),
+					Value: channel.Id,
+				})
+			}
+		}
+	}
+	return options
+}
+
+// deliveryDefault is the option of the settings dialog showing the delivery, the bot DM channel
+// when the chosen channel isn't listed anymore
+func deliveryDefault(options []*model.PostActionOptions, delivery string) string {
+	for _, option := range options {
+		if option.Value == delivery {
+			return delivery
+		}
+	}
+	return constant.DELIVERY_DM
+}
@@ -0,0 +1,251 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"google.golang.org/api/calendar/v3"
+)
+
+// digestMode returns the digest mode of the user, off when they never chose one
+func digestMode(settings models.UserSettings) string {
+	switch settings.DigestMode {
+	case constant.DIGEST_MODE_HOURLY, constant.DIGEST_MODE_TWICE_DAILY:
+		return settings.DigestMode
+	default:
+		return constant.DIGEST_MODE_OFF
+	}
+}
+
+// isQuietHours tells whether now is in the quiet hours of the user, now being in their timezone.
+// Quiet hours ending before they start go over midnight
+func isQuietHours(settings models.UserSettings, now time.Time) bool {
+	if settings.QuietHoursStart == "" || settings.QuietHoursEnd == "" {
+		return false
+	}
+	clock := now.Format(constant.REMINDER_TIME_FORMAT)
+	start, end := settings.QuietHoursStart, settings.QuietHoursEnd
+	if start <= end {
+		return clock >= start && clock < end
+	}
+	return clock >= start || clock < end
+}
+
+// isImminentEvent tells whether the event is going on or starts within
+// URGENT_NOTIFICATION_MINUTES, the changes of such events are always sent at once
+func isImminentEvent(event *calendar.Event, now time.Time) bool {
+	et := newEventTime(event, now.Location())
+	if et.AllDay || et.IsOver(now) {
+		return false
+	}
+	return et.Start.Before(now.Add(constant.URGENT_NOTIFICATION_MINUTES * time.Minute))
+}
+
+// isDigestDue tells whether the changes queued since oldest are posted at now. Nothing is posted
+// during the quiet hours, a digest that fell in them is posted as soon as they are over
+func isDigestDue(settings models.UserSettings, oldest, now time.Time) bool {
+	if isQuietHours(settings, now) {
+		return false
+	}
+	switch digestMode(settings) {
+	case constant.DIGEST_MODE_HOURLY:
+		return now.Minute() == 0 || now.Sub(oldest) >= time.Hour
+	case constant.DIGEST_MODE_TWICE_DAILY:
+		clock := now.Format(constant.REMINDER_TIME_FORMAT)
+		return clock == constant.DIGEST_MORNING_TIME || clock == constant.DIGEST_EVENING_TIME ||
+			now.Sub(oldest) >= 12*time.Hour
+	default:
+		return true
+	}
+}
+
+// queueNotifications keeps the changes for the next digest of the user. Only the event and what it
+// was before are kept, the digest prints them when it is posted
+func (p *Plugin) queueNotifications(userID string, changes eventChangeSet, now time.Time) error {
+	if changes.isEmpty() {
+		return nil
+	}
+
+	p.digestLock.Lock()
+	defer p.digestLock.Unlock()
+
+	queue, err := p.getNotificationQueue(userID)
+	if err != nil {
+		return err
+	}
+	for _, section := range changes.sections() {
+		for _, change := range section.changes {
+			queued := models.QueuedNotification{
+				EventID:  change.event.Id,
+				Section:  section.name,
+				QueuedAt: now,
+			}
+			if change.oldEvent != nil {
+				if queued.OldEvent, err = json.Marshal(change.oldEvent); err != nil {
+					return err
+				}
+			}
+			queue = append(queue, queued)
+		}
+	}
+	return p.setNotificationQueue(userID, queue)
+}
+
+// postNotificationDigest posts the queued changes of the user once their digest is due. The queue
+// is only emptied once the digest is posted
+func (p *Plugin) postNotificationDigest(user models.UserDataDto) {
+	format := p.getTimeFormat(user.UserID)
+	queue, err := p.getDueNotifications(user.UserID, user.Settings, time.Now().In(format.location))
+	if err != nil {
+		p.API.LogError("Error getting queued notifications", "err", err.Error(), "userID", user.UserID)
+		return
+	}
+	if len(queue) == 0 {
+		return
+	}
+
+	events, err := p.getStoredEvents(user.UserID)
+	if err != nil {
+		return
+	}
+	location, err := p.getPrimaryCalendarLocation(user.UserID)
+	if err != nil {
+		p.API.LogError("Error getting primary calendar location", "err", err.Error(), "userID", user.UserID)
+		return
+	}
+	printed := p.printEventChanges(user.UserID, user.Settings, queuedChanges(queue, events, location), events, location)
+
+	if all := printed.all(); len(all) > 0 {
+		title := format.localizer.N("digest.title", len(all), len(all))
+		delivery := notificationDelivery(user.Settings, constant.NOTIFICATION_DIGESTS)
+		for _, text := range printChangePosts(title, printed, format.localizer) {
+			if appErr := p.CreateBotNotificationPost(user.UserID, delivery, text, nil); appErr != nil {
+				p.API.LogError("Error creating bot post", "err", appErr.Error(), "userID", user.UserID)
+				return
+			}
+		}
+	}
+	if err := p.dropNotifications(user.UserID, len(queue)); err != nil {
+		p.API.LogError("Error emptying notification queue", "err", err.Error(), "userID", user.UserID)
+	}
+}
+
+// queuedChanges turns the queue back into changes against the stored events. An event queued
+// several times in a section is shown once, from its oldest state to its current one. The events
+// cancelled since are only shown as cancelled, and the new ones only as new
+func queuedChanges(queue []models.QueuedNotification, events []*calendar.Event, location *time.Location) eventChangeSet {
+	current := make(map[string]*calendar.Event, len(events))
+	for _, event := range events {
+		current[event.Id] = event
+	}
+	added := make(map[string]bool)
+	for _, queued := range queue {
+		if queued.Section == "added" {
+			added[queued.EventID] = true
+		}
+	}
+
+	var changes eventChangeSet
+	seen := make(map[string]bool)
+	for _, queued := range queue {
+		key := queued.Section + "/" + queued.EventID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var oldEvent *calendar.Event
+		if len(queued.OldEvent) > 0 {
+			if err := json.Unmarshal(queued.OldEvent, &oldEvent); err != nil {
+				continue
+			}
+		}
+		event := current[queued.EventID]
+		switch queued.Section {
+		case "cancelled":
+			if oldEvent == nil {
+				continue
+			}
+			event = &calendar.Event{Id: queued.EventID, Status: constant.EV_STATUS_CANCELLED}
+		case "added":
+			if event == nil {
+				continue
+			}
+		default:
+			if event == nil || oldEvent == nil || added[queued.EventID] {
+				continue
+			}
+			// the event may have changed back since
+			if queued.Section == "updated" && !diffEvents(oldEvent, event, location).HasChange() {
+				continue
+			}
+		}
+		changes.add(queued.Section, eventChange{event: event, oldEvent: oldEvent})
+	}
+	return changes
+}
+
+// getDueNotifications returns the queue of the user when their digest is due
+func (p *Plugin) getDueNotifications(userID string, settings models.UserSettings, now time.Time) ([]models.QueuedNotification, error) {
+	p.digestLock.Lock()
+	defer p.digestLock.Unlock()
+
+	queue, err := p.getNotificationQueue(userID)
+	if err != nil || len(queue) == 0 {
+		return nil, err
+	}
+	if !isDigestDue(settings, queue[0].QueuedAt, now) {
+		return nil, nil
+	}
+	return queue, nil
+}
+
+// dropNotifications removes the first count notifications of the queue once they are posted, the
+// ones queued in the meantime are kept
+func (p *Plugin) dropNotifications(userID string, count int) error {
+	p.digestLock.Lock()
+	defer p.digestLock.Unlock()
+
+	queue, err := p.getNotificationQueue(userID)
+	if err != nil {
+		return err
+	}
+	if count > len(queue) {
+		count = len(queue)
+	}
+	return p.setNotificationQueue(userID, queue[count:])
+}
+
+func (p *Plugin) getNotificationQueue(userID string) ([]models.QueuedNotification, error) {
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.NOTIFICATION_QUEUE_KEY,
+	})
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var queue []models.QueuedNotification
+	if err := json.Unmarshal([]byte(lookup.Value), &queue); err != nil {
+		return nil, err
+	}
+	return queue, nil
+}
+
+func (p *Plugin) setNotificationQueue(userID string, queue []models.QueuedNotification) error {
+	value, err := json.Marshal(queue)
+	if err != nil {
+		return err
+	}
+	return p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.NOTIFICATION_QUEUE_KEY,
+		Value:  string(value),
+	})
+}
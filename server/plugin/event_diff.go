@@ -0,0 +1,234 @@
+package plugin
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	// maxDiffWords bounds the word diff of descriptions, longer descriptions are only reported as changed
+	maxDiffWords = 400
+
+	// diffContextWords is how many unchanged words are kept around each description edit
+	diffContextWords = 3
+)
+
+// eventDiff is what changed between two versions of the same event
+type eventDiff struct {
+	SummaryChanged     bool
+	TimeChanged        bool
+	LocationChanged    bool
+	StatusChanged      bool
+	DescriptionChanged bool
+	MeetLinkChanged    bool
+	RecurrenceChanged  bool
+
+	AddedAttendees   []*calendar.EventAttendee
+	RemovedAttendees []*calendar.EventAttendee
+
+	// Responses are the other attendees who answered differently, only reported on events the
+	// user organizes
+	Responses []*calendar.EventAttendee
+}
+
+// diffEvents compares the old and the changed version of an event
+func diffEvents(oldEvent, changedEvent *calendar.Event, location *time.Location) eventDiff {
+	oldTime := newEventTime(oldEvent, location)
+	changedTime := newEventTime(changedEvent, location)
+
+	diff := eventDiff{
+		SummaryChanged:     oldEvent.Summary != changedEvent.Summary,
+		TimeChanged:        !oldTime.Start.Equal(changedTime.Start) || !oldTime.End.Equal(changedTime.End) || oldTime.AllDay != changedTime.AllDay,
+		LocationChanged:    oldEvent.Location != changedEvent.Location,
+		StatusChanged:      oldEvent.Status != changedEvent.Status,
+		DescriptionChanged: oldEvent.Description != changedEvent.Description,
+		MeetLinkChanged:    meetLink(oldEvent) != meetLink(changedEvent),
+		RecurrenceChanged:  strings.Join(oldEvent.Recurrence, "\n") != strings.Join(changedEvent.Recurrence, "\n"),
+	}
+
+	oldAttendees := make(map[string]*calendar.EventAttendee)
+	for _, attendee := range oldEvent.Attendees {
+		oldAttendees[strings.ToLower(attendee.Email)] = attendee
+	}
+	changedAttendees := make(map[string]bool)
+	for _, attendee := range changedEvent.Attendees {
+		email := strings.ToLower(attendee.Email)
+		changedAttendees[email] = true
+
+		oldAttendee, ok := oldAttendees[email]
+		if !ok {
+			diff.AddedAttendees = append(diff.AddedAttendees, attendee)
+			continue
+		}
+		if oldAttendee.ResponseStatus != attendee.ResponseStatus && !attendee.Self &&
+			changedEvent.Organizer != nil && changedEvent.Organizer.Self {
+			diff.Responses = append(diff.Responses, attendee)
+		}
+	}
+	for _, attendee := range oldEvent.Attendees {
+		if !changedAttendees[strings.ToLower(attendee.Email)] {
+			diff.RemovedAttendees = append(diff.RemovedAttendees, attendee)
+		}
+	}
+	return diff
+}
+
+// HasChange tells whether the event itself changed, answers of the attendees are not counted
+func (d eventDiff) HasChange() bool {
+	return d.SummaryChanged || d.TimeChanged || d.LocationChanged || d.StatusChanged || d.DescriptionChanged ||
+		d.MeetLinkChanged || d.RecurrenceChanged || len(d.AddedAttendees) > 0 || len(d.RemovedAttendees) > 0
+}
+
+// meetLink is the video call link of the event, if any
+func meetLink(event *calendar.Event) string {
+	if event.HangoutLink != "" {
+		return event.HangoutLink
+	}
+	if event.ConferenceData != nil {
+		for _, entryPoint := range event.ConferenceData.EntryPoints {
+			if entryPoint.EntryPointType == "video" {
+				return entryPoint.Uri
+			}
+		}
+	}
+	return ""
+}
+
+// printRecurrence prints the recurrence rules of an event without their RRULE: prefix
+func printRecurrence(recurrence []string, localizer *i18n.Localizer) string {
+	if len(recurrence) == 0 {
+		return localizer.T("event.repeats.none")
+	}
+	rules := make([]string, 0, len(recurrence))
+	for _, rule := range recurrence {
+		rules = append(rules, "`"+strings.TrimPrefix(rule, "RRULE:")+"`")
+	}
+	return strings.Join(rules, ", ")
+}
+
+// printAttendee prints the attendee as a Mattermost @mention when they have an account, otherwise
+// by the name or the email Google knows them by
+func (p *Plugin) printAttendee(attendee *calendar.EventAttendee) string {
+	if user := p.services.attendeeService.UserByEmail(attendee.Email); user != nil {
+		return "@" + user.Username
+	}
+	if attendee.DisplayName != "" {
+		return attendee.DisplayName
+	}
+	return attendee.Email
+}
+
+func (p *Plugin) printAttendees(attendees []*calendar.EventAttendee) string {
+	names := make([]string, 0, len(attendees))
+	for _, attendee := range attendees {
+		names = append(names, p.printAttendee(attendee))
+	}
+	return strings.Join(names, ", ")
+}
+
+// printResponse prints what an attendee answered, for example "@bob declined"
+func (p *Plugin) printResponse(attendee *calendar.EventAttendee, localizer *i18n.Localizer) string {
+	answer := "response.status.pending"
+	switch attendee.ResponseStatus {
+	case constant.EV_STATUS_ACCEPTED:
+		answer = "response.status.accepted"
+	case constant.EV_STATUS_DECLINED:
+		answer = "response.status.declined"
+	case constant.EV_STATUS_TENTATIVE:
+		answer = "response.status.tentative"
+	}
+	return localizer.T(answer, p.printAttendee(attendee))
+}
+
+// printTextDiff prints a short word diff of two texts, removed words are struck through and added
+// words are bold. Unchanged words far from any edit are elided. Texts too long to diff, or that only
+// differ in their spacing, are only reported as changed
+func printTextDiff(oldText, newText string) string {
+	oldWords := strings.Fields(oldText)
+	newWords := strings.Fields(newText)
+	if len(oldWords)+len(newWords) > maxDiffWords {
+		return "_(changed)_"
+	}
+
+	// longest common subsequence of the words
+	lcs := make([][]int, len(oldWords)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newWords)+1)
+	}
+	for i := len(oldWords) - 1; i >= 0; i-- {
+		for j := len(newWords) - 1; j >= 0; j-- {
+			if oldWords[i] == newWords[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type wordOp struct {
+		word string
+		op   byte // '=', '-' or '+'
+	}
+	var ops []wordOp
+	i, j := 0, 0
+	for i < len(oldWords) || j < len(newWords) {
+		switch {
+		case i < len(oldWords) && j < len(newWords) && oldWords[i] == newWords[j]:
+			ops = append(ops, wordOp{oldWords[i], '='})
+			i++
+			j++
+		case i < len(oldWords) && (j == len(newWords) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, wordOp{oldWords[i], '-'})
+			i++
+		default:
+			ops = append(ops, wordOp{newWords[j], '+'})
+			j++
+		}
+	}
+
+	// keep the unchanged words close to an edit
+	keep := make([]bool, len(ops))
+	edited := false
+	for k, op := range ops {
+		if op.op == '=' {
+			continue
+		}
+		edited = true
+		for c := k - diffContextWords; c <= k+diffContextWords; c++ {
+			if c >= 0 && c < len(ops) {
+				keep[c] = true
+			}
+		}
+	}
+	if !edited {
+		return "_(changed)_"
+	}
+
+	var words []string
+	elided := false
+	for k, op := range ops {
+		if !keep[k] {
+			if !elided {
+				words = append(words, "…")
+				elided = true
+			}
+			continue
+		}
+		elided = false
+		switch op.op {
+		case '-':
+			words = append(words, "~~"+op.word+"~~")
+		case '+':
+			words = append(words, "**"+op.word+"**")
+		default:
+			words = append(words, op.word)
+		}
+	}
+	return strings.Join(words, " ")
+}
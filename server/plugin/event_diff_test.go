@@ -0,0 +1,352 @@
+package plugin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	"google.golang.org/api/calendar/v3"
+)
+
+func newDiffEvent() *calendar.Event {
+	return &calendar.Event{
+		Id:          "event",
+		Summary:     "Planning",
+		Location:    "Room 1",
+		Status:      "confirmed",
+		Description: "Agenda for the week",
+		HangoutLink: "https://meet.google.com/abc-defg-hij",
+		Start:       &calendar.EventDateTime{DateTime: "2024-03-05T10:00:00Z"},
+		End:         &calendar.EventDateTime{DateTime: "2024-03-05T11:00:00Z"},
+		Recurrence:  []string{"RRULE:FREQ=WEEKLY"},
+		Creator:     &calendar.EventCreator{Email: "me@example.com", Self: true},
+		Organizer:   &calendar.EventOrganizer{Email: "me@example.com", Self: true},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "me@example.com", ResponseStatus: constant.EV_STATUS_ACCEPTED, Self: true, Organizer: true},
+			{Email: "bob@example.com", ResponseStatus: constant.EV_STATUS_NEED_ACTION},
+		},
+	}
+}
+
+func copyDiffEvent(event *calendar.Event) *calendar.Event {
+	changed := *event
+	changed.Attendees = nil
+	for _, attendee := range event.Attendees {
+		a := *attendee
+		changed.Attendees = append(changed.Attendees, &a)
+	}
+	return &changed
+}
+
+func attendeeEmails(attendees []*calendar.EventAttendee) string {
+	emails := make([]string, 0, len(attendees))
+	for _, attendee := range attendees {
+		emails = append(emails, attendee.Email)
+	}
+	return strings.Join(emails, ",")
+}
+
+func TestDiffEvents(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    func(event *calendar.Event)
+		want      eventDiff
+		added     string
+		removed   string
+		responses string
+		hasChange bool
+	}{
+		{
+			name:   "nothing",
+			change: func(event *calendar.Event) {},
+		},
+		{
+			name:      "title",
+			change:    func(event *calendar.Event) { event.Summary = "Retro" },
+			want:      eventDiff{SummaryChanged: true},
+			hasChange: true,
+		},
+		{
+			name: "time",
+			change: func(event *calendar.Event) {
+				event.Start = &calendar.EventDateTime{DateTime: "2024-03-05T11:00:00Z"}
+				event.End = &calendar.EventDateTime{DateTime: "2024-03-05T12:00:00Z"}
+			},
+			want:      eventDiff{TimeChanged: true},
+			hasChange: true,
+		},
+		{
+			name: "same instant in another zone",
+			change: func(event *calendar.Event) {
+				event.Start = &calendar.EventDateTime{DateTime: "2024-03-05T05:00:00-05:00", TimeZone: "America/New_York"}
+				event.End = &calendar.EventDateTime{DateTime: "2024-03-05T06:00:00-05:00", TimeZone: "America/New_York"}
+			},
+		},
+		{
+			name: "to all day",
+			change: func(event *calendar.Event) {
+				event.Start = &calendar.EventDateTime{Date: "2024-03-05"}
+				event.End = &calendar.EventDateTime{Date: "2024-03-06"}
+			},
+			want:      eventDiff{TimeChanged: true},
+			hasChange: true,
+		},
+		{
+			name:      "location",
+			change:    func(event *calendar.Event) { event.Location = "Room 2" },
+			want:      eventDiff{LocationChanged: true},
+			hasChange: true,
+		},
+		{
+			name:      "meet link removed",
+			change:    func(event *calendar.Event) { event.HangoutLink = "" },
+			want:      eventDiff{MeetLinkChanged: true},
+			hasChange: true,
+		},
+		{
+			name: "meet link moved to the conference data",
+			change: func(event *calendar.Event) {
+				event.HangoutLink = ""
+				event.ConferenceData = &calendar.ConferenceData{EntryPoints: []*calendar.EntryPoint{
+					{EntryPointType: "phone", Uri: "tel:+1-555-0100"},
+					{EntryPointType: "video", Uri: "https://meet.google.com/abc-defg-hij"},
+				}}
+			},
+		},
+		{
+			name:      "description",
+			change:    func(event *calendar.Event) { event.Description = "Agenda for the month" },
+			want:      eventDiff{DescriptionChanged: true},
+			hasChange: true,
+		},
+		{
+			name: "guest added",
+			change: func(event *calendar.Event) {
+				event.Attendees = append(event.Attendees, &calendar.EventAttendee{Email: "carol@example.com"})
+			},
+			added:     "carol@example.com",
+			hasChange: true,
+		},
+		{
+			name:      "guest removed",
+			change:    func(event *calendar.Event) { event.Attendees = event.Attendees[:1] },
+			removed:   "bob@example.com",
+			hasChange: true,
+		},
+		{
+			name:   "guest email case",
+			change: func(event *calendar.Event) { event.Attendees[1].Email = "Bob@Example.com" },
+		},
+		{
+			name:      "recurrence",
+			change:    func(event *calendar.Event) { event.Recurrence = []string{"RRULE:FREQ=DAILY"} },
+			want:      eventDiff{RecurrenceChanged: true},
+			hasChange: true,
+		},
+		{
+			name:      "recurrence removed",
+			change:    func(event *calendar.Event) { event.Recurrence = nil },
+			want:      eventDiff{RecurrenceChanged: true},
+			hasChange: true,
+		},
+		{
+			name:      "status",
+			change:    func(event *calendar.Event) { event.Status = "tentative" },
+			want:      eventDiff{StatusChanged: true},
+			hasChange: true,
+		},
+		{
+			name:      "guest answered",
+			change:    func(event *calendar.Event) { event.Attendees[1].ResponseStatus = constant.EV_STATUS_DECLINED },
+			responses: "bob@example.com",
+		},
+		{
+			name:   "own answer",
+			change: func(event *calendar.Event) { event.Attendees[0].ResponseStatus = constant.EV_STATUS_TENTATIVE },
+		},
+		{
+			name: "guest answered an event organized by someone else",
+			change: func(event *calendar.Event) {
+				event.Organizer = &calendar.EventOrganizer{Email: "alice@example.com"}
+				event.Attendees[1].ResponseStatus = constant.EV_STATUS_DECLINED
+			},
+		},
+		{
+			name: "guest answered and title",
+			change: func(event *calendar.Event) {
+				event.Summary = "Retro"
+				event.Attendees[1].ResponseStatus = constant.EV_STATUS_ACCEPTED
+			},
+			want:      eventDiff{SummaryChanged: true},
+			responses: "bob@example.com",
+			hasChange: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldEvent := newDiffEvent()
+			changedEvent := copyDiffEvent(oldEvent)
+			tt.change(changedEvent)
+
+			diff := diffEvents(oldEvent, changedEvent, time.UTC)
+			flags := diff
+			flags.AddedAttendees, flags.RemovedAttendees, flags.Responses = nil, nil, nil
+			if !reflect.DeepEqual(flags, tt.want) {
+				t.Errorf("diffEvents() = %+v, want %+v", flags, tt.want)
+			}
+			if got := attendeeEmails(diff.AddedAttendees); got != tt.added {
+				t.Errorf("AddedAttendees = %q, want %q", got, tt.added)
+			}
+			if got := attendeeEmails(diff.RemovedAttendees); got != tt.removed {
+				t.Errorf("RemovedAttendees = %q, want %q", got, tt.removed)
+			}
+			if got := attendeeEmails(diff.Responses); got != tt.responses {
+				t.Errorf("Responses = %q, want %q", got, tt.responses)
+			}
+			if got := diff.HasChange(); got != tt.hasChange {
+				t.Errorf("HasChange() = %v, want %v", got, tt.hasChange)
+			}
+		})
+	}
+}
+
+func TestNotifiedChanges(t *testing.T) {
+	own := newDiffEvent()
+	answered := copyDiffEvent(own)
+	answered.Attendees[1].ResponseStatus = constant.EV_STATUS_DECLINED
+	answeredAndMoved := copyDiffEvent(answered)
+	answeredAndMoved.Location = "Room 2"
+	moved := copyDiffEvent(own)
+	moved.Location = "Room 2"
+
+	others := copyDiffEvent(own)
+	others.Creator = &calendar.EventCreator{Email: "alice@example.com"}
+	others.Organizer = &calendar.EventOrganizer{Email: "alice@example.com"}
+	othersMoved := copyDiffEvent(others)
+	othersMoved.Location = "Room 2"
+
+	changes := eventChangeSet{
+		Added: []eventChange{{event: own}, {event: others}},
+		Updated: []eventChange{
+			{event: moved, oldEvent: own},
+			{event: answeredAndMoved, oldEvent: own},
+			{event: othersMoved, oldEvent: others},
+		},
+		Cancelled: []eventChange{
+			{event: &calendar.Event{Id: own.Id, Status: constant.EV_STATUS_CANCELLED}, oldEvent: own},
+			{event: &calendar.Event{Id: others.Id, Status: constant.EV_STATUS_CANCELLED}, oldEvent: others},
+		},
+		RSVPChanged: []eventChange{{event: answered, oldEvent: own}},
+	}
+	notified := notifiedChanges(changes, time.UTC)
+
+	if len(notified.Added) != 1 || notified.Added[0].event != others {
+		t.Errorf("Added = %v, want only the event of someone else", notified.Added)
+	}
+	if len(notified.Updated) != 1 || notified.Updated[0].event != othersMoved {
+		t.Errorf("Updated = %v, want only the event of someone else", notified.Updated)
+	}
+	if len(notified.Cancelled) != 1 || notified.Cancelled[0].oldEvent != others {
+		t.Errorf("Cancelled = %v, want only the event of someone else", notified.Cancelled)
+	}
+	if len(notified.RSVPChanged) != 2 || notified.RSVPChanged[0].event != answered || notified.RSVPChanged[1].event != answeredAndMoved {
+		t.Errorf("RSVPChanged = %v, want the answers to the own events", notified.RSVPChanged)
+	}
+	if len(changes.RSVPChanged) != 1 {
+		t.Errorf("notifiedChanges changed its argument: %v", changes.RSVPChanged)
+	}
+}
+
+func TestMeetLink(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *calendar.Event
+		want  string
+	}{
+		{"none", &calendar.Event{}, ""},
+		{"hangout link", &calendar.Event{HangoutLink: "https://meet.google.com/a"}, "https://meet.google.com/a"},
+		{
+			"video entry point",
+			&calendar.Event{ConferenceData: &calendar.ConferenceData{EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "phone", Uri: "tel:+1-555-0100"},
+				{EntryPointType: "video", Uri: "https://zoom.us/j/1"},
+			}}},
+			"https://zoom.us/j/1",
+		},
+		{
+			"phone only",
+			&calendar.Event{ConferenceData: &calendar.ConferenceData{EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "phone", Uri: "tel:+1-555-0100"},
+			}}},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		if got := meetLink(tt.event); got != tt.want {
+			t.Errorf("%s: meetLink() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrintRecurrence(t *testing.T) {
+	tests := []struct {
+		recurrence []string
+		want       string
+	}{
+		{nil, "Does not repeat"},
+		{[]string{"RRULE:FREQ=WEEKLY;BYDAY=MO"}, "`FREQ=WEEKLY;BYDAY=MO`"},
+		{
+			[]string{"RRULE:FREQ=DAILY;COUNT=5", "EXDATE;VALUE=DATE:20240307"},
+			"`FREQ=DAILY;COUNT=5`, `EXDATE;VALUE=DATE:20240307`",
+		},
+	}
+	for _, tt := range tests {
+		if got := printRecurrence(tt.recurrence, i18n.NewLocalizer(i18n.DefaultLocale)); got != tt.want {
+			t.Errorf("printRecurrence(%q) = %q, want %q", tt.recurrence, got, tt.want)
+		}
+	}
+}
+
+func TestPrintTextDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    string
+	}{
+		{"word replaced", "meet in room one", "meet in room two", "meet in room ~~one~~ **two**"},
+		{"word added", "bring laptops", "bring your laptops", "bring **your** laptops"},
+		{"word removed", "bring your laptops", "bring laptops", "bring ~~your~~ laptops"},
+		{"from nothing", "", "new text", "**new** **text**"},
+		{"to nothing", "old text", "", "~~old~~ ~~text~~"},
+		{"whitespace only", "a  b\nc", "a b c", "_(changed)_"},
+		{
+			"far from the edit",
+			"one two three four five six seven eight nine ten",
+			"one two three four five six seven eight nine eleven",
+			"… seven eight nine ~~ten~~ **eleven**",
+		},
+		{
+			"two edits",
+			"a b c d e f g h i j k l m n",
+			"x b c d e f g h i j k l m y",
+			"~~a~~ **x** b c d … k l m ~~n~~ **y**",
+		},
+	}
+	for _, tt := range tests {
+		if got := printTextDiff(tt.oldText, tt.newText); got != tt.want {
+			t.Errorf("%s: printTextDiff() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrintTextDiffLongText(t *testing.T) {
+	long := strings.Repeat("word ", maxDiffWords)
+	if got := printTextDiff(long, long+"more"); got != "_(changed)_" {
+		t.Errorf("printTextDiff() = %q, want _(changed)_", got)
+	}
+}
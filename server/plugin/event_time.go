@@ -0,0 +1,211 @@
+package plugin
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"google.golang.org/api/calendar/v3"
+)
+
+// eventTime is the normalized start and end of an event. Timed events keep their instants, all-day
+// events only have dates so they start and end at midnight in the calendar's location. Like Google,
+// the end is exclusive, an all-day event on Jan 1 ends on Jan 2 at midnight.
+type eventTime struct {
+	Start  time.Time
+	End    time.Time
+	AllDay bool
+
+	// Zone is the time zone the event was created in, empty when Google didn't give it
+	Zone string
+}
+
+func newEventTime(event *calendar.Event, location *time.Location) eventTime {
+	start, startAllDay := parseEventDateTime(event.Start, location)
+	end, _ := parseEventDateTime(event.End, location)
+	if end.Before(start) {
+		end = start
+	}
+	et := eventTime{
+		Start:  start,
+		End:    end,
+		AllDay: startAllDay,
+	}
+	if !startAllDay && event.Start != nil {
+		et.Zone = event.Start.TimeZone
+	}
+	return et
+}
+
+// In moves the event to the given location, all-day events keep their dates and start and end at
+// midnight in the new location
+func (et eventTime) In(location *time.Location) eventTime {
+	if et.AllDay {
+		et.Start = time.Date(et.Start.Year(), et.Start.Month(), et.Start.Day(), 0, 0, 0, 0, location)
+		et.End = time.Date(et.End.Year(), et.End.Month(), et.End.Day(), 0, 0, 0, 0, location)
+		return et
+	}
+	et.Start = et.Start.In(location)
+	et.End = et.End.In(location)
+	return et
+}
+
+// parseEventDateTime returns the instant of an event boundary and whether it is a date only
+func parseEventDateTime(dt *calendar.EventDateTime, location *time.Location) (time.Time, bool) {
+	if dt == nil {
+		return time.Time{}, false
+	}
+	if dt.DateTime == "" {
+		date, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, dt.Date, location)
+		if err != nil {
+			return time.Time{}, true
+		}
+		return date, true
+	}
+	t, err := time.Parse(time.RFC3339, dt.DateTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.In(location), false
+}
+
+// dateOf truncates t to midnight of its day in the given location
+func dateOf(t time.Time, location *time.Location) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
+
+// daysBetween counts the calendar days from a to b, it is not affected by DST transitions
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+// FirstDay is the date the event starts on
+func (et eventTime) FirstDay() time.Time {
+	return dateOf(et.Start, et.Start.Location())
+}
+
+// LastDay is the last date the event covers, the end is exclusive so an event ending at midnight
+// does not cover the next day
+func (et eventTime) LastDay() time.Time {
+	if !et.End.After(et.Start) {
+		return et.FirstDay()
+	}
+	return dateOf(et.End.Add(-time.Nanosecond), et.Start.Location())
+}
+
+// Days is the number of days the event covers
+func (et eventTime) Days() int {
+	return daysBetween(et.FirstDay(), et.LastDay()) + 1
+}
+
+// IsMultiDay tells whether the event covers more than one day
+func (et eventTime) IsMultiDay() bool {
+	return et.Days() > 1
+}
+
+// DayNumber returns which day of the event the given date is, starting at 1, or 0 when the event
+// does not cover that date
+func (et eventTime) DayNumber(day time.Time) int {
+	n := daysBetween(et.FirstDay(), day) + 1
+	if n < 1 || n > et.Days() {
+		return 0
+	}
+	return n
+}
+
+// allDayReminderAt is when an all-day event is reminded: on its first day, at the time of day chosen
+// by the user. A time skipped by a DST change is still reminded on that day, at a time time.Date picks
+func allDayReminderAt(et eventTime, reminderTime time.Time, location *time.Location) time.Time {
+	firstDay := et.FirstDay()
+	return time.Date(firstDay.Year(), firstDay.Month(), firstDay.Day(), reminderTime.Hour(), reminderTime.Minute(), 0, 0, location)
+}
+
+// IsOver tells whether the event has ended at the given time
+func (et eventTime) IsOver(now time.Time) bool {
+	return !now.Before(et.End)
+}
+
+// Overlaps tells whether two events share some time, touching events do not overlap
+func (et eventTime) Overlaps(other eventTime) bool {
+	return et.Start.Before(other.End) && other.Start.Before(et.End)
+}
+
+// before orders events by start, all-day events come first on the same start
+func (et eventTime) before(other eventTime) bool {
+	if !et.Start.Equal(other.Start) {
+		return et.Start.Before(other.Start)
+	}
+	if et.AllDay != other.AllDay {
+		return et.AllDay
+	}
+	return et.End.Before(other.End)
+}
+
+// sortEvents orders events chronologically, using the location to place all-day events
+func sortEvents(events []*calendar.Event, location *time.Location) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return newEventTime(events[i], location).before(newEventTime(events[j], location))
+	})
+}
+
+// printDateLabel prints Today or Tomorrow for the near dates and the full date otherwise
+func printDateLabel(day time.Time, now time.Time, format timeFormat) string {
+	switch daysBetween(now, day) {
+	case 0:
+		return format.T("date.today")
+	case 1:
+		return format.T("date.tomorrow")
+	default:
+		return format.Date(day)
+	}
+}
+
+// printEventWhen prints when an event happens for the viewer. When day is set and the event covers
+// more than one day, it also tells which day of the event that is, for example "Day 2 of 3". An event
+// created in another time zone than the viewer's also shows its times in that zone
+func printEventWhen(et eventTime, format timeFormat, day time.Time) string {
+	et = et.In(format.location)
+	now := time.Now().In(format.location)
+
+	var text string
+	switch {
+	case !et.IsMultiDay() && et.AllDay:
+		text = format.T("event.when.all_day", printDateLabel(et.FirstDay(), now, format))
+	case !et.IsMultiDay():
+		text = format.T("event.when.time", printDateLabel(et.FirstDay(), now, format),
+			format.Time(et.Start), format.Time(et.End))
+	case et.AllDay:
+		text = format.T("event.when.all_days", printDateLabel(et.FirstDay(), now, format), printDateLabel(et.LastDay(), now, format))
+	default:
+		text = format.T("event.when.times", printDateLabel(et.FirstDay(), now, format), format.Time(et.Start),
+			printDateLabel(et.LastDay(), now, format), format.Time(et.End))
+	}
+
+	if !day.IsZero() && et.IsMultiDay() {
+		if n := et.DayNumber(day); n > 0 {
+			text += format.T("event.when.day_of", n, et.Days())
+		}
+	}
+	return text + printEventZone(et, format)
+}
+
+// printEventZone prints the times of the event in the zone it was created in, when that zone isn't
+// at the same offset as the viewer's
+func printEventZone(et eventTime, format timeFormat) string {
+	if et.AllDay || et.Zone == "" {
+		return ""
+	}
+	zone, err := time.LoadLocation(et.Zone)
+	if err != nil {
+		return ""
+	}
+	_, eventOffset := et.Start.In(zone).Zone()
+	_, viewerOffset := et.Start.In(format.location).Zone()
+	if eventOffset == viewerOffset {
+		return ""
+	}
+	return format.T("event.when.zone", format.Clock(et.Start.In(zone)), format.Clock(et.End.In(zone)), et.Zone)
+}
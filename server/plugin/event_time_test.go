@@ -0,0 +1,408 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	"google.golang.org/api/calendar/v3"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s not available: %v", name, err)
+	}
+	return location
+}
+
+func timedEvent(start, end, zone string) *calendar.Event {
+	return &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: start, TimeZone: zone},
+		End:   &calendar.EventDateTime{DateTime: end, TimeZone: zone},
+	}
+}
+
+func allDayEvent(start, end string) *calendar.Event {
+	return &calendar.Event{
+		Start: &calendar.EventDateTime{Date: start},
+		End:   &calendar.EventDateTime{Date: end},
+	}
+}
+
+func TestNewEventTime(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+
+	tests := []struct {
+		name     string
+		event    *calendar.Event
+		location *time.Location
+		start    time.Time
+		end      time.Time
+		allDay   bool
+		zone     string
+		days     int
+	}{
+		{
+			name:     "timed",
+			event:    timedEvent("2024-03-05T10:00:00Z", "2024-03-05T11:00:00Z", ""),
+			location: newYork,
+			start:    time.Date(2024, 3, 5, 5, 0, 0, 0, newYork),
+			end:      time.Date(2024, 3, 5, 6, 0, 0, 0, newYork),
+			days:     1,
+		},
+		{
+			name:     "timed with its zone",
+			event:    timedEvent("2024-03-05T10:00:00+00:00", "2024-03-05T11:00:00+00:00", "Europe/London"),
+			location: newYork,
+			start:    time.Date(2024, 3, 5, 5, 0, 0, 0, newYork),
+			end:      time.Date(2024, 3, 5, 6, 0, 0, 0, newYork),
+			zone:     "Europe/London",
+			days:     1,
+		},
+		{
+			name:     "ending at midnight",
+			event:    timedEvent("2024-03-05T22:00:00Z", "2024-03-06T00:00:00Z", ""),
+			location: london,
+			start:    time.Date(2024, 3, 5, 22, 0, 0, 0, london),
+			end:      time.Date(2024, 3, 6, 0, 0, 0, 0, london),
+			days:     1,
+		},
+		{
+			name:     "ending before its start",
+			event:    timedEvent("2024-03-05T10:00:00Z", "2024-03-05T09:00:00Z", ""),
+			location: time.UTC,
+			start:    time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+			days:     1,
+		},
+		{
+			name:     "all day",
+			event:    allDayEvent("2024-03-05", "2024-03-06"),
+			location: newYork,
+			start:    time.Date(2024, 3, 5, 0, 0, 0, 0, newYork),
+			end:      time.Date(2024, 3, 6, 0, 0, 0, 0, newYork),
+			allDay:   true,
+			days:     1,
+		},
+		{
+			name:     "all day over the spring DST change",
+			event:    allDayEvent("2024-03-09", "2024-03-12"),
+			location: newYork,
+			start:    time.Date(2024, 3, 9, 0, 0, 0, 0, newYork),
+			end:      time.Date(2024, 3, 12, 0, 0, 0, 0, newYork),
+			allDay:   true,
+			days:     3,
+		},
+		{
+			name:     "all day over the autumn DST change",
+			event:    allDayEvent("2024-10-26", "2024-10-28"),
+			location: london,
+			start:    time.Date(2024, 10, 26, 0, 0, 0, 0, london),
+			end:      time.Date(2024, 10, 28, 0, 0, 0, 0, london),
+			allDay:   true,
+			days:     2,
+		},
+		{
+			name:     "overnight over the spring DST change",
+			event:    timedEvent("2024-03-30T22:00:00Z", "2024-03-31T02:00:00Z", "Europe/London"),
+			location: london,
+			start:    time.Date(2024, 3, 30, 22, 0, 0, 0, london),
+			end:      time.Date(2024, 3, 31, 3, 0, 0, 0, london),
+			zone:     "Europe/London",
+			days:     2,
+		},
+		{
+			name:     "overnight over the autumn DST change",
+			event:    timedEvent("2024-11-03T00:30:00-04:00", "2024-11-03T01:30:00-05:00", "America/New_York"),
+			location: newYork,
+			start:    time.Date(2024, 11, 3, 0, 30, 0, 0, newYork),
+			end:      time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC),
+			zone:     "America/New_York",
+			days:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			et := newEventTime(tt.event, tt.location)
+			if !et.Start.Equal(tt.start) || !et.End.Equal(tt.end) {
+				t.Errorf("newEventTime() = %v to %v, want %v to %v", et.Start, et.End, tt.start, tt.end)
+			}
+			if et.Start.Location() != tt.location {
+				t.Errorf("Start is in %v, want %v", et.Start.Location(), tt.location)
+			}
+			if et.AllDay != tt.allDay {
+				t.Errorf("AllDay = %v, want %v", et.AllDay, tt.allDay)
+			}
+			if et.Zone != tt.zone {
+				t.Errorf("Zone = %q, want %q", et.Zone, tt.zone)
+			}
+			if got := et.Days(); got != tt.days {
+				t.Errorf("Days() = %d, want %d", got, tt.days)
+			}
+		})
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+
+	tests := []struct {
+		name string
+		a, b time.Time
+		want int
+	}{
+		{"same day", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 5, 23, 59, 0, 0, time.UTC), 0},
+		{"next day", time.Date(2024, 3, 5, 23, 0, 0, 0, time.UTC), time.Date(2024, 3, 6, 1, 0, 0, 0, time.UTC), 1},
+		{"backwards", time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), -1},
+		{"new year", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1},
+		{"leap day", time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 2},
+		{"spring DST in New York", time.Date(2024, 3, 9, 0, 0, 0, 0, newYork), time.Date(2024, 3, 11, 0, 0, 0, 0, newYork), 2},
+		{"autumn DST in New York", time.Date(2024, 11, 2, 0, 0, 0, 0, newYork), time.Date(2024, 11, 4, 0, 0, 0, 0, newYork), 2},
+		{"spring DST in London", time.Date(2024, 3, 30, 0, 0, 0, 0, london), time.Date(2024, 4, 1, 0, 0, 0, 0, london), 2},
+		{"autumn DST in London", time.Date(2024, 10, 27, 0, 0, 0, 0, london), time.Date(2024, 10, 28, 0, 0, 0, 0, london), 1},
+		// the dates are read in the location of each time
+		{"different zones", time.Date(2024, 3, 5, 23, 0, 0, 0, newYork), time.Date(2024, 3, 6, 4, 0, 0, 0, london), 1},
+	}
+	for _, tt := range tests {
+		if got := daysBetween(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: daysBetween() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDayNumber(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+
+	allDay := newEventTime(allDayEvent("2024-03-09", "2024-03-12"), newYork)
+	overnight := newEventTime(timedEvent("2024-03-30T22:00:00Z", "2024-03-31T02:00:00Z", ""), london)
+	tests := []struct {
+		name string
+		et   eventTime
+		day  time.Time
+		want int
+	}{
+		{"all day, before", allDay, time.Date(2024, 3, 8, 0, 0, 0, 0, newYork), 0},
+		{"all day, first day", allDay, time.Date(2024, 3, 9, 0, 0, 0, 0, newYork), 1},
+		{"all day, DST change", allDay, time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), 2},
+		{"all day, last day", allDay, time.Date(2024, 3, 11, 0, 0, 0, 0, newYork), 3},
+		{"all day, end date", allDay, time.Date(2024, 3, 12, 0, 0, 0, 0, newYork), 0},
+		{"all day, day of another zone", allDay, time.Date(2024, 3, 11, 0, 0, 0, 0, london), 3},
+		{"overnight, first day", overnight, time.Date(2024, 3, 30, 0, 0, 0, 0, london), 1},
+		{"overnight, DST change", overnight, time.Date(2024, 3, 31, 0, 0, 0, 0, london), 2},
+		{"overnight, after", overnight, time.Date(2024, 4, 1, 0, 0, 0, 0, london), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.et.DayNumber(tt.day); got != tt.want {
+			t.Errorf("%s: DayNumber() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPrintEventWhen(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+
+	tests := []struct {
+		name     string
+		event    *calendar.Event
+		calendar *time.Location
+		viewer   *time.Location
+		military bool
+		day      time.Time
+		want     string
+	}{
+		{
+			name:     "all day",
+			event:    allDayEvent("2024-03-05", "2024-03-06"),
+			calendar: newYork,
+			viewer:   newYork,
+			want:     "Tuesday, March 5, 2024 @ All-day",
+		},
+		{
+			name:     "all day, viewer behind the calendar",
+			event:    allDayEvent("2024-03-05", "2024-03-06"),
+			calendar: tokyo,
+			viewer:   newYork,
+			want:     "Tuesday, March 5, 2024 @ All-day",
+		},
+		{
+			name:     "all day, viewer ahead of the calendar",
+			event:    allDayEvent("2024-03-05", "2024-03-06"),
+			calendar: newYork,
+			viewer:   tokyo,
+			want:     "Tuesday, March 5, 2024 @ All-day",
+		},
+		{
+			name:     "all days over the DST change",
+			event:    allDayEvent("2024-03-09", "2024-03-12"),
+			calendar: newYork,
+			viewer:   newYork,
+			day:      time.Date(2024, 3, 10, 0, 0, 0, 0, newYork),
+			want:     "Saturday, March 9, 2024 to Monday, March 11, 2024 (All-day) (Day 2 of 3)",
+		},
+		{
+			name:     "all days, viewer in another zone",
+			event:    allDayEvent("2024-03-09", "2024-03-12"),
+			calendar: tokyo,
+			viewer:   london,
+			day:      time.Date(2024, 3, 11, 0, 0, 0, 0, london),
+			want:     "Saturday, March 9, 2024 to Monday, March 11, 2024 (All-day) (Day 3 of 3)",
+		},
+		{
+			name:     "timed",
+			event:    timedEvent("2024-03-05T10:00:00Z", "2024-03-05T11:30:00Z", "Europe/London"),
+			calendar: london,
+			viewer:   london,
+			want:     "Tuesday, March 5, 2024 @ 10:00 AM GMT to 11:30 AM GMT",
+		},
+		{
+			name:     "timed, military",
+			event:    timedEvent("2024-03-05T13:00:00Z", "2024-03-05T14:00:00Z", "Europe/London"),
+			calendar: london,
+			viewer:   london,
+			military: true,
+			want:     "Tuesday, March 5, 2024 @ 13:00 GMT to 14:00 GMT",
+		},
+		{
+			name:     "timed, viewer in another zone",
+			event:    timedEvent("2024-03-05T10:00:00Z", "2024-03-05T11:00:00Z", "Europe/London"),
+			calendar: london,
+			viewer:   newYork,
+			want:     "Tuesday, March 5, 2024 @ 5:00 AM EST to 6:00 AM EST (10:00 AM to 11:00 AM Europe/London)",
+		},
+		{
+			name:     "timed, viewer's zone already on DST",
+			event:    timedEvent("2024-03-20T14:00:00Z", "2024-03-20T15:00:00Z", "Europe/London"),
+			calendar: london,
+			viewer:   newYork,
+			want:     "Wednesday, March 20, 2024 @ 10:00 AM EDT to 11:00 AM EDT (2:00 PM to 3:00 PM Europe/London)",
+		},
+		{
+			name:     "timed, other zone at the same offset",
+			event:    timedEvent("2024-03-05T10:00:00Z", "2024-03-05T11:00:00Z", "Europe/Lisbon"),
+			calendar: london,
+			viewer:   london,
+			want:     "Tuesday, March 5, 2024 @ 10:00 AM GMT to 11:00 AM GMT",
+		},
+		{
+			name:     "timed, the viewer's day differs",
+			event:    timedEvent("2024-03-05T20:00:00-05:00", "2024-03-05T21:00:00-05:00", "America/New_York"),
+			calendar: newYork,
+			viewer:   tokyo,
+			military: true,
+			want:     "Wednesday, March 6, 2024 @ 10:00 JST to 11:00 JST (20:00 to 21:00 America/New_York)",
+		},
+		{
+			name:     "overnight over the DST change",
+			event:    timedEvent("2024-03-30T22:00:00Z", "2024-03-31T02:00:00Z", "Europe/London"),
+			calendar: london,
+			viewer:   london,
+			military: true,
+			day:      time.Date(2024, 3, 31, 0, 0, 0, 0, london),
+			want:     "Saturday, March 30, 2024 @ 22:00 GMT to Sunday, March 31, 2024 @ 03:00 BST (Day 2 of 2)",
+		},
+		{
+			name:     "overnight for the viewer only",
+			event:    timedEvent("2024-03-05T09:00:00+09:00", "2024-03-05T11:00:00+09:00", "Asia/Tokyo"),
+			calendar: tokyo,
+			viewer:   newYork,
+			military: true,
+			day:      time.Date(2024, 3, 4, 0, 0, 0, 0, newYork),
+			want:     "Monday, March 4, 2024 @ 19:00 EST to 21:00 EST (09:00 to 11:00 Asia/Tokyo)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := timeFormat{location: tt.viewer, military: tt.military, localizer: i18n.NewLocalizer(i18n.DefaultLocale)}
+			et := newEventTime(tt.event, tt.calendar)
+			if got := printEventWhen(et, format, tt.day); got != tt.want {
+				t.Errorf("printEventWhen() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// The reminders are sent on the minute they are due, a reminder time skipped by a DST change must
+// still be a minute of the first day
+func TestAllDayReminderAtSkippedTime(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	reminderTime, err := time.Parse("15:04", "02:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := allDayReminderAt(newEventTime(allDayEvent("2024-03-10", "2024-03-11"), newYork), reminderTime, newYork)
+	if got.Year() != 2024 || got.Month() != time.March || got.Day() != 10 {
+		t.Errorf("allDayReminderAt() = %v, want a time on March 10", got)
+	}
+	minute := time.Date(got.Year(), got.Month(), got.Day(), got.Hour(), got.Minute(), 0, 0, newYork)
+	if !minute.Equal(got) {
+		t.Errorf("allDayReminderAt() = %v is never the current minute, %v is", got, minute)
+	}
+}
+
+func TestAllDayReminderAt(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+
+	tests := []struct {
+		name     string
+		event    *calendar.Event
+		location *time.Location
+		reminder string
+		want     time.Time
+	}{
+		{
+			name:     "first day",
+			event:    allDayEvent("2024-03-05", "2024-03-08"),
+			location: newYork,
+			reminder: "09:00",
+			want:     time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DST day in New York",
+			event:    allDayEvent("2024-03-10", "2024-03-11"),
+			location: newYork,
+			reminder: "09:00",
+			want:     time.Date(2024, 3, 10, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "DST day in London",
+			event:    allDayEvent("2024-03-31", "2024-04-01"),
+			location: london,
+			reminder: "09:00",
+			want:     time.Date(2024, 3, 31, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "autumn DST day in London",
+			event:    allDayEvent("2024-10-27", "2024-10-28"),
+			location: london,
+			reminder: "09:00",
+			want:     time.Date(2024, 10, 27, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminderTime, err := time.Parse("15:04", tt.reminder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := allDayReminderAt(newEventTime(tt.event, tt.location), reminderTime, tt.location)
+			if !got.Equal(tt.want) {
+				t.Errorf("allDayReminderAt() = %v, want %v", got, tt.want)
+			}
+			if got.Location() != tt.location {
+				t.Errorf("allDayReminderAt() is in %v, want %v", got.Location(), tt.location)
+			}
+		})
+	}
+}
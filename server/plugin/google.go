@@ -0,0 +1,193 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+)
+
+const (
+	// googleRequestTimeout is the deadline of every request to Google, retries included
+	googleRequestTimeout = 30 * time.Second
+
+	// googleMaxAttempts is how many times a rate limited request is sent
+	googleMaxAttempts = 5
+
+	googleBackoffBase = 500 * time.Millisecond
+	googleBackoffMax  = 8 * time.Second
+)
+
+// googleTransport gives a deadline to the requests to Google and cancels them when the plugin is
+// deactivated. Rate limited requests and server errors are retried with an exponential backoff,
+// and the token of the user is marked invalid when Google refuses it
+type googleTransport struct {
+	base   http.RoundTripper
+	ctx    context.Context
+	userID string
+	plugin *Plugin
+}
+
+func (t *googleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), googleRequestTimeout)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-t.ctx.Done():
+			cancel()
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			close(done)
+			cancel()
+		})
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req.Clone(ctx)
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				release()
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			release()
+			if errors.Is(apperr.FromGoogle(err), apperr.ErrTokenRevoked) {
+				t.plugin.markTokenInvalid(t.userID)
+			}
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			t.plugin.markTokenInvalid(t.userID)
+		}
+
+		// requests with a body that can't be sent again are not retried
+		canRetry := attempt < googleMaxAttempts && (req.Body == nil || req.GetBody != nil)
+		if !canRetry || !shouldRetryGoogle(req, resp) {
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: release}
+			return resp, nil
+		}
+
+		resp.Body.Close()
+		select {
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		case <-time.After(googleBackoff(attempt)):
+		}
+	}
+}
+
+// shouldRetryGoogle tells whether the response is a rate limit, or a server error of a request that
+// can be sent again safely. A POST may have been done despite the error, sending it again could
+// create the event twice. The body of a 403 is read to find the reason and put back
+func shouldRetryGoogle(req *http.Request, resp *http.Response) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return isIdempotentMethod(req.Method)
+	case resp.StatusCode != http.StatusForbidden:
+		return false
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(body, []byte("rateLimitExceeded")) || bytes.Contains(body, []byte("userRateLimitExceeded"))
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// googleBackoff is an exponential backoff with full jitter
+func googleBackoff(attempt int) time.Duration {
+	backoff := googleBackoffBase << (attempt - 1)
+	if backoff > googleBackoffMax {
+		backoff = googleBackoffMax
+	}
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
+
+// cancelOnClose releases the context of a request once its response is read
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// markTokenInvalid remembers that Google refused the token of the user and asks them to connect
+// again, only the first time
+func (p *Plugin) markTokenInvalid(userID string) {
+	if p.isTokenInvalid(userID) {
+		return
+	}
+	value, err := json.Marshal(time.Now())
+	if err != nil {
+		p.API.LogError("Error marshalling time", "err", err.Error())
+		return
+	}
+	if err := p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.TOKEN_INVALID_KEY,
+		Value:  string(value),
+	}); err != nil {
+		p.API.LogError("Error marking token invalid", "err", err.Error(), "userID", userID)
+		return
+	}
+	if appErr := p.CreateBotDMPost(userID, p.userMessage(userID, apperr.ErrTokenRevoked)); appErr != nil {
+		p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+	}
+}
+
+// tokenInvalidSince returns when Google refused the token of the user, zero when it didn't
+func (p *Plugin) tokenInvalidSince(userID string) time.Time {
+	var since time.Time
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.TOKEN_INVALID_KEY,
+	})
+	if err != nil {
+		return since
+	}
+	if err := json.Unmarshal([]byte(lookup.Value), &since); err != nil {
+		p.API.LogError("Error unmarshalling time", "err", err.Error())
+	}
+	return since
+}
+
+func (p *Plugin) isTokenInvalid(userID string) bool {
+	return !p.tokenInvalidSince(userID).IsZero()
+}
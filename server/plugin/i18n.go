@@ -0,0 +1,30 @@
+package plugin
+
+import (
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+)
+
+// getLocalizer returns the localizer of the user's Mattermost language
+func (p *Plugin) getLocalizer(userID string) *i18n.Localizer {
+	mmUser, appErr := p.API.GetUser(userID)
+	if appErr != nil {
+		return i18n.NewLocalizer(i18n.DefaultLocale)
+	}
+	return i18n.NewLocalizer(mmUser.Locale)
+}
+
+// getServerLocalizer returns the localizer of the default language of the server, for the texts
+// shared by every user like the autocomplete of the command
+func (p *Plugin) getServerLocalizer() *i18n.Localizer {
+	config := p.API.GetConfig()
+	if config == nil || config.LocalizationSettings.DefaultServerLocale == nil {
+		return i18n.NewLocalizer(i18n.DefaultLocale)
+	}
+	return i18n.NewLocalizer(*config.LocalizationSettings.DefaultServerLocale)
+}
+
+// userMessage tells the user what went wrong, in their language
+func (p *Plugin) userMessage(userID string, err error) string {
+	return apperr.LocalizedMessage(p.getLocalizer(userID), err)
+}
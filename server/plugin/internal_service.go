@@ -11,10 +11,11 @@ import (
 )
 
 type InternalService struct {
-	db            *gorm.DB
-	userService   service.UserService
-	lookupService service.LookupService
-	stateService  service.ConnectStateService
+	db              *gorm.DB
+	userService     service.UserService
+	lookupService   service.LookupService
+	stateService    service.ConnectStateService
+	attendeeService service.AttendeeService
 }
 
 func (p *Plugin) ConnectDB() (*gorm.DB, error) {
@@ -71,6 +72,10 @@ func (p *Plugin) NewInternalService() *InternalService {
 		log.Fatalf("failed to connect database: %v", err.Error())
 	}
 
+	if err := p.metrics.instrumentDB(db); err != nil {
+		p.API.LogError("failed to instrument database", "err", err.Error())
+	}
+
 	// state
 	stateRepo := repository.NewConnectStateRepository(db)
 	stateService := service.NewConnectStateService(stateRepo)
@@ -87,6 +92,8 @@ func (p *Plugin) NewInternalService() *InternalService {
 		userService:   userService,
 		lookupService: lookupService,
 		stateService:  stateService,
+		// attendees
+		attendeeService: service.NewAttendeeService(p.API),
 	}
 }
 
@@ -0,0 +1,212 @@
+package plugin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/metrics"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+const metricsNamespace = "gcal_plugin_"
+
+// pluginMetrics are the metrics exposed on the /metrics route
+type pluginMetrics struct {
+	registry *metrics.Registry
+
+	googleRequests       *metrics.CounterVec
+	googleLatency        *metrics.HistogramVec
+	tokenRefreshFailures *metrics.CounterVec
+	syncs                *metrics.CounterVec
+	syncDuration         *metrics.HistogramVec
+	reminderLag          *metrics.HistogramVec
+	webhookHits          *metrics.CounterVec
+	dbQueryDuration      *metrics.HistogramVec
+	queueDepth           *metrics.GaugeVec
+}
+
+func newPluginMetrics() *pluginMetrics {
+	registry := metrics.NewRegistry()
+	return &pluginMetrics{
+		registry: registry,
+		googleRequests: registry.NewCounterVec(metricsNamespace+"google_api_requests_total",
+			"Google Calendar API requests by method and status.", "method", "status"),
+		googleLatency: registry.NewHistogramVec(metricsNamespace+"google_api_request_duration_seconds",
+			"Latency of the Google Calendar API requests.", nil, "method"),
+		tokenRefreshFailures: registry.NewCounterVec(metricsNamespace+"token_refresh_failures_total",
+			"OAuth token refreshes that failed."),
+		syncs: registry.NewCounterVec(metricsNamespace+"syncs_total",
+			"Calendar syncs by type, full or incremental, and result.", "type", "result"),
+		syncDuration: registry.NewHistogramVec(metricsNamespace+"sync_duration_seconds",
+			"Duration of the calendar syncs.", nil, "type"),
+		reminderLag: registry.NewHistogramVec(metricsNamespace+"reminder_lag_seconds",
+			"Delay between when a reminder is due and when it is posted.", []float64{1, 5, 10, 30, 60, 120, 300}),
+		webhookHits: registry.NewCounterVec(metricsNamespace+"webhook_requests_total",
+			"Push notifications received from Google by resource state.", "state"),
+		dbQueryDuration: registry.NewHistogramVec(metricsNamespace+"db_query_duration_seconds",
+			"Duration of the database queries by operation and table.", nil, "operation", "table"),
+		queueDepth: registry.NewGaugeVec(metricsNamespace+"queue_depth",
+			"Users waiting or being processed by the background jobs.", "queue"),
+	}
+}
+
+// webhookState is the label of a push notification, Google only sends these states and anything
+// else is counted together so the callers can't create labels
+func webhookState(state string) string {
+	switch state {
+	case "sync", "exists", "not_exists":
+		return state
+	default:
+		return "other"
+	}
+}
+
+func syncType(isIncrementalSync bool) string {
+	if isIncrementalSync {
+		return "incremental"
+	}
+	return "full"
+}
+
+// observeSync records the duration and the result of a sync
+func (m *pluginMetrics) observeSync(isIncrementalSync bool, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.syncs.Inc(syncType(isIncrementalSync), result)
+	m.syncDuration.Observe(time.Since(start).Seconds(), syncType(isIncrementalSync))
+}
+
+// instrumentedTransport counts the requests made to Google and how long they take
+type instrumentedTransport struct {
+	base    http.RoundTripper
+	metrics *pluginMetrics
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := googleAPIMethod(req)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.metrics.googleLatency.Observe(time.Since(start).Seconds(), method)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	t.metrics.googleRequests.Inc(method, status)
+	return resp, err
+}
+
+// googleAPIPathWords are the parts of the Google Calendar API paths that are not IDs
+var googleAPIPathWords = map[string]bool{
+	"calendar": true, "v3": true, "calendars": true, "events": true, "watch": true, "stop": true,
+	"channels": true, "freeBusy": true, "users": true, "me": true, "calendarList": true, "settings": true,
+	"instances": true, "import": true, "quickAdd": true, "move": true, "colors": true, "acl": true,
+}
+
+// googleAPIMethod names the API method of a request like "GET /calendars/{id}/events", IDs are
+// replaced so the label keeps a small number of values
+func googleAPIMethod(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	var parts []string
+	for _, segment := range segments {
+		if segment == "calendar" || segment == "v3" {
+			continue
+		}
+		if !googleAPIPathWords[segment] {
+			segment = "{id}"
+		}
+		parts = append(parts, segment)
+	}
+	return req.Method + " /" + strings.Join(parts, "/")
+}
+
+// instrumentedTokenSource counts the tokens that couldn't be refreshed
+type instrumentedTokenSource struct {
+	base    oauth2.TokenSource
+	metrics *pluginMetrics
+}
+
+func (s *instrumentedTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.base.Token()
+	if err != nil {
+		s.metrics.tokenRefreshFailures.Inc()
+	}
+	return token, err
+}
+
+// instrumentDB times every database query with gorm callbacks
+func (m *pluginMetrics) instrumentDB(db *gorm.DB) error {
+	const startKey = "metrics:start"
+	before := func(db *gorm.DB) {
+		db.InstanceSet(startKey, time.Now())
+	}
+	after := func(operation string) func(*gorm.DB) {
+		return func(db *gorm.DB) {
+			value, ok := db.InstanceGet(startKey)
+			if !ok {
+				return
+			}
+			start, ok := value.(time.Time)
+			if !ok {
+				return
+			}
+			m.dbQueryDuration.Observe(time.Since(start).Seconds(), operation, db.Statement.Table)
+		}
+	}
+
+	callback := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(string) error
+		after     func(string) error
+	}{
+		{"create",
+			func(name string) error { return callback.Create().Before("gorm:create").Register(name, before) },
+			func(name string) error { return callback.Create().After("gorm:create").Register(name, after("create")) }},
+		{"query",
+			func(name string) error { return callback.Query().Before("gorm:query").Register(name, before) },
+			func(name string) error { return callback.Query().After("gorm:query").Register(name, after("query")) }},
+		{"update",
+			func(name string) error { return callback.Update().Before("gorm:update").Register(name, before) },
+			func(name string) error { return callback.Update().After("gorm:update").Register(name, after("update")) }},
+		{"delete",
+			func(name string) error { return callback.Delete().Before("gorm:delete").Register(name, before) },
+			func(name string) error { return callback.Delete().After("gorm:delete").Register(name, after("delete")) }},
+		{"row",
+			func(name string) error { return callback.Row().Before("gorm:row").Register(name, before) },
+			func(name string) error { return callback.Row().After("gorm:row").Register(name, after("row")) }},
+		{"raw",
+			func(name string) error { return callback.Raw().Before("gorm:raw").Register(name, before) },
+			func(name string) error { return callback.Raw().After("gorm:raw").Register(name, after("raw")) }},
+	}
+	for _, processor := range processors {
+		if err := processor.before("metrics:before_" + processor.operation); err != nil {
+			return err
+		}
+		if err := processor.after("metrics:after_" + processor.operation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serveMetrics exposes the metrics to the system admins
+func (p *Plugin) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get(constant.MATTERMOST_USER_KEY)
+	if userID == "" || !p.API.HasPermissionTo(userID, model.PERMISSION_MANAGE_SYSTEM) {
+		http.Error(w, "Not authorized", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if err := p.metrics.registry.WriteText(w); err != nil {
+		p.API.LogError("Error writing metrics", "err", err.Error())
+	}
+}
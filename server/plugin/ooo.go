@@ -0,0 +1,172 @@
+package plugin
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/mattermost/mattermost-server/v5/plugin"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	// oooReplyInterval is how long a sender doesn't get the same out of office reply again
+	oooReplyInterval = 4 * time.Hour
+
+	oooEventType = "outOfOffice"
+)
+
+// replyLimiter remembers when a sender was last told about someone being out of office
+type replyLimiter struct {
+	mutex   sync.Mutex
+	replies map[string]time.Time
+}
+
+func newReplyLimiter() *replyLimiter {
+	return &replyLimiter{replies: make(map[string]time.Time)}
+}
+
+// allow tells whether the sender can be replied about the user, and records the reply when it can
+func (l *replyLimiter) allow(senderID, userID string, now time.Time) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := senderID + ":" + userID
+	if last, ok := l.replies[key]; ok && now.Sub(last) < oooReplyInterval {
+		return false
+	}
+	// forget the old replies so the map doesn't grow forever
+	for k, last := range l.replies {
+		if now.Sub(last) >= oooReplyInterval {
+			delete(l.replies, k)
+		}
+	}
+	l.replies[key] = now
+	return true
+}
+
+// MessageHasBeenPosted tells the sender of a DM or of an @mention when the user is out of office
+func (p *Plugin) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
+	if post.UserId == p.botID || post.IsSystemMessage() || post.GetProp("from_webhook") == "true" {
+		return
+	}
+
+	for _, userID := range p.oooRecipients(post) {
+		if userID == post.UserId {
+			continue
+		}
+		p.replyOutOfOffice(post, userID)
+	}
+}
+
+// oooRecipients are the users the post is for, the other member of a DM and the mentioned users
+func (p *Plugin) oooRecipients(post *model.Post) []string {
+	var userIDs []string
+	channel, appErr := p.API.GetChannel(post.ChannelId)
+	if appErr != nil {
+		p.API.LogError("Error getting channel", "err", appErr.Error())
+		return nil
+	}
+	if channel.Type == model.CHANNEL_DIRECT {
+		if otherID := channel.GetOtherUserIdForDM(post.UserId); otherID != "" && otherID != p.botID {
+			userIDs = append(userIDs, otherID)
+		}
+	}
+
+	for _, mention := range model.PossibleAtMentions(post.Message) {
+		user, appErr := p.API.GetUserByUsername(mention)
+		if appErr != nil || user.IsBot {
+			continue
+		}
+		found := false
+		for _, userID := range userIDs {
+			if userID == user.Id {
+				found = true
+			}
+		}
+		if !found {
+			userIDs = append(userIDs, user.Id)
+		}
+	}
+	return userIDs
+}
+
+func (p *Plugin) replyOutOfOffice(post *model.Post, userID string) {
+	secret := p.getConfiguration().EncryptionSecret
+	user, err := p.services.userService.GetUserByID(userID, secret)
+	if err != nil || !user.Settings.OOOAutoReply {
+		return
+	}
+
+	events, err := p.getStoredEvents(userID)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	event := findOutOfOfficeEvent(events, now)
+	if event == nil || !p.oooReplies.allow(post.UserId, userID, now) {
+		return
+	}
+
+	mmUser, appErr := p.API.GetUser(userID)
+	if appErr != nil {
+		p.API.LogError("Error getting user", "err", appErr.Error())
+		return
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+	p.API.SendEphemeralPost(post.UserId, &model.Post{
+		UserId:    p.botID,
+		ChannelId: post.ChannelId,
+		RootId:    rootID,
+		Message:   printOutOfOffice(mmUser.Username, event, user.Settings.OOOBackupContact, p.getTimeFormat(post.UserId)),
+	})
+}
+
+// findOutOfOfficeEvent returns the out of office event happening at the given time, if any
+func findOutOfOfficeEvent(events []*calendar.Event, now time.Time) *calendar.Event {
+	for _, event := range events {
+		if event.EventType != oooEventType || event.Status == constant.EV_STATUS_CANCELLED {
+			continue
+		}
+		// out of office events always have a time so they don't depend on the location
+		et := newEventTime(event, time.UTC)
+		if !now.Before(et.Start) && now.Before(et.End) {
+			return event
+		}
+	}
+	return nil
+}
+
+// printOutOfOffice prints "@alice is out of office until Mon 21 Oct" with the message of the event
+// and who to contact instead, in the format of the sender
+func printOutOfOffice(username string, event *calendar.Event, backupContact string, format timeFormat) string {
+	until := printOutOfOfficeDate(newEventTime(event, format.location).End, format)
+	text := format.T("ooo.reply", username, until)
+	message := strings.TrimSpace(event.Description)
+	if message == "" && event.Summary != "" && !strings.EqualFold(event.Summary, "Out of office") {
+		message = event.Summary
+	}
+	if message != "" {
+		text += "\n> " + strings.ReplaceAll(message, "\n", "\n> ")
+	}
+	if backupContact != "" {
+		text += format.T("ooo.backup", backupContact)
+	}
+	return text
+}
+
+// printOutOfOfficeDate prints a boundary of an out of office event, the time is left out at midnight
+func printOutOfOfficeDate(t time.Time, format timeFormat) string {
+	t = t.In(format.location)
+	text := format.ShortDate(t)
+	if t.Hour() != 0 || t.Minute() != 0 {
+		text += " " + format.Clock(t)
+	}
+	return text
+}
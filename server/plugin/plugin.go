@@ -1,17 +1,21 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/mattermost/mattermost-server/v5/plugin"
 	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
 	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
 )
 
@@ -29,8 +33,31 @@ type Plugin struct {
 	configurationLock sync.RWMutex
 	services          *InternalService
 	router            *mux.Router
+	oooReplies        *replyLimiter
+	metrics           *pluginMetrics
+	cron              *cron.Cron
+	calendarCache     *calendarCache
+
+	// remindersLock serializes the changes to the snoozed reminders and muted events of the users
+	remindersLock sync.Mutex
+
+	// teamDigestLock serializes the subscriptions of the channels to the "who's out" post
+	teamDigestLock sync.Mutex
+
+	// pendingSyncRetries counts the startup syncs waiting to be retried, for the admin stats
+	pendingSyncRetries int32
+
+	// digestLock serializes the changes to the notification queues of the users
+	digestLock sync.Mutex
+
+	// ctx is cancelled when the plugin is deactivated, to stop the background jobs
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
+// startupSyncRetries are the delays before syncing again a user whose sync failed at startup
+var startupSyncRetries = []time.Duration{30 * time.Second, 2 * time.Minute, 10 * time.Minute}
+
 // ServeHTTP allows the plugin to implement the http.Handler interface. Requests destined for the
 // /plugins/{id} path will be routed to the plugin.
 //
@@ -51,6 +78,7 @@ func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Req
 //OnActivate function ensures what bot does when become actived
 func (p *Plugin) OnActivate() error {
 	p.API.LogInfo("Google Calendar Plugin activating...")
+	p.ctx, p.cancel = context.WithCancel(context.Background())
 
 	// get command
 	command, err := p.getCommand()
@@ -92,12 +120,14 @@ func (p *Plugin) OnActivate() error {
 	p.API.LogInfo("Google Calendar Plugin profile image was set")
 
 	// init internal service
+	p.metrics = newPluginMetrics()
+	p.calendarCache = newCalendarCache()
 	p.services = p.NewInternalService()
 	p.API.LogInfo("Google Calendar Plugin internal service was created")
 
-	if err := p.SyncUserData(); err != nil {
-		return errors.Wrap(err, "failed to load user data")
-	}
+	// the calendars are synced in the background so one user can't prevent the activation
+	go p.SyncUserData()
+	p.oooReplies = newReplyLimiter()
 	p.registerRouter()
 	p.notifyCronJob()
 	p.API.LogInfo("Google Calendar Plugin activate successfully")
@@ -106,6 +136,13 @@ func (p *Plugin) OnActivate() error {
 
 func (p *Plugin) OnDeactivate() error {
 	p.API.LogInfo("Google Calendar Plugin deactivating...")
+	// stop the jobs and cancel the requests to Google in flight
+	if p.cron != nil {
+		p.cron.Stop()
+	}
+	if p.cancel != nil {
+		p.cancel()
+	}
 	// close db
 	if err := p.CloseDb(); err != nil {
 		p.API.LogError("failed to close database", "err", err)
@@ -116,49 +153,63 @@ func (p *Plugin) OnDeactivate() error {
 	return nil
 }
 
-func (p *Plugin) SyncUserData() error {
+// SyncUserData syncs the calendar of every connected user
+func (p *Plugin) SyncUserData() {
 	p.API.LogInfo("Google Calendar Plugin loading user data...")
-	page := 1
-	limit := 100
-	secret := p.getConfiguration().EncryptionSecret
-
-	for {
-		result, err := p.services.userService.List(secret, models.ListUsersOption{
-			Page:  page,
-			Limit: limit,
+	p.forEachUser("startup_sync", "", p.SetupUserSync)
+	p.API.LogInfo("Google Calendar Plugin user data was loaded")
+}
+
+// SetupUserSync syncs the calendar of the user at startup. A failed sync is retried later from a
+// timer, so the user doesn't hold a worker of forEachUser while waiting
+func (p *Plugin) SetupUserSync(user models.UserDataDto) {
+	p.syncUserWithRetries(user, 0)
+}
+
+// syncUserWithRetries syncs the calendar of the user, the attempt-th retry after startupSyncRetries.
+// The error is kept for the admin stats and the user is told once when every attempt failed
+func (p *Plugin) syncUserWithRetries(user models.UserDataDto, attempt int) {
+	if p.ctx.Err() != nil {
+		return
+	}
+	err := apperr.FromGoogle(p.CalendarSyncV2(p.ctx, user))
+	if err == nil {
+		p.API.LogInfo("Google Calendar Plugin user data was synced", "user_id", user.UserID)
+		return
+	}
+
+	// a revoked token won't be accepted by retrying
+	if !errors.Is(err, apperr.ErrTokenRevoked) && attempt < len(startupSyncRetries) {
+		delay := startupSyncRetries[attempt]
+		p.API.LogWarn("failed to sync calendar, retrying", "err", err.Error(), "user_id", user.UserID, "delay", delay.String())
+		atomic.AddInt32(&p.pendingSyncRetries, 1)
+		time.AfterFunc(delay, func() {
+			defer atomic.AddInt32(&p.pendingSyncRetries, -1)
+			p.syncUserWithRetries(user, attempt+1)
 		})
-		if err != nil {
-			p.API.LogError("failed to get users", "err", err)
-			return err
-		}
-		if len(result.Users) == 0 {
-			break
-		}
-		var wg sync.WaitGroup
-		wg.Add(len(result.Users))
-		maxGoroutines := 20
-		queues := make(chan struct{}, maxGoroutines)
-		for _, user := range result.Users {
-			queues <- struct{}{}
-			go func(user models.UserDataDto) {
-				defer func() {
-					wg.Done()
-					<-queues
-				}()
-				p.SetupUserSync(user)
-			}(user)
-		}
-		wg.Wait()
-		page++
+		return
 	}
 
-	return nil
+	p.API.LogError("failed to sync calendar", "err", err.Error(), "user_id", user.UserID)
+	// the user was already asked to connect again when their token was refused
+	if errors.Is(err, apperr.ErrTokenRevoked) {
+		return
+	}
+	p.notifySyncFailure(user.UserID, err)
 }
 
-func (p *Plugin) SetupUserSync(user models.UserDataDto) {
-	if err := p.CalendarSyncV2(user); err != nil {
-		p.API.LogError("failed to sync calendar", "err", err)
-		log.Fatal(err)
+// notifySyncFailure tells the user their calendar can't be synced, only once until a sync works
+// again
+func (p *Plugin) notifySyncFailure(userID string, err error) {
+	status := p.getSyncStatus(userID)
+	if status.FailureNotified {
+		return
+	}
+	message := p.getLocalizer(userID).T("sync.failed", p.userMessage(userID, err))
+	if appErr := p.CreateBotDMPost(userID, message); appErr != nil {
+		p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+		return
 	}
-	p.API.LogInfo("Google Calendar Plugin user data was synced", "user_id", user.UserID)
+	status.FailureNotified = true
+	p.setSyncStatus(userID, status)
 }
@@ -0,0 +1,336 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/templates"
+	"google.golang.org/api/calendar/v3"
+)
+
+// postReminder posts the reminder of an event in its thread, with the card of the event and the
+// buttons to snooze or mute the reminder
+func (p *Plugin) postReminder(userID string, settings models.UserSettings, event *calendar.Event, reminder templates.Reminder) *model.AppError {
+	format := p.getTimeFormat(userID)
+	reminder.Localized = templates.Localized{Localizer: format.localizer}
+	reminder.Event = p.newTemplateEvent(event, format, time.Time{})
+	reminder.Event.Card = true
+	card := p.newReminderCard(userID, event, format)
+	delivery := notificationDelivery(settings, constant.NOTIFICATION_REMINDERS)
+	if appErr := p.CreateBotNotificationEventPost(userID, delivery, event, p.renderTemplate(templates.ReminderTemplate, reminder), card); appErr != nil {
+		p.API.LogError("Unable to create bot DM post", "userID", userID)
+		return appErr
+	}
+	return nil
+}
+
+// newReminderCard is the card of the event with the buttons of the reminders
+func (p *Plugin) newReminderCard(userID string, event *calendar.Event, format timeFormat) *model.SlackAttachment {
+	card := p.newEventCard(userID, event, format, time.Time{})
+	for _, action := range card.Actions {
+		if action.Integration.Context["action"] == constant.EVENT_ACTION_JOIN {
+			action.Name = format.T("reminder.join")
+		}
+	}
+
+	actions := []*model.PostAction{
+		p.newReminderAction(format.T("reminder.snooze", constant.SNOOZE_MINUTES), event, constant.REMINDER_ACTION_SNOOZE),
+	}
+	if et := newEventTime(event, format.location); !et.AllDay && et.Start.After(time.Now()) {
+		actions = append(actions, p.newReminderAction(format.T("reminder.snooze_until_start"), event, constant.REMINDER_ACTION_SNOOZE_UNTIL_START))
+	}
+	card.Actions = append(actions, card.Actions...)
+	card.Actions = append(card.Actions, p.newReminderAction(format.T("reminder.mute"), event, constant.REMINDER_ACTION_MUTE))
+	return card
+}
+
+// isReminderCard tells whether the card has the buttons of a reminder
+func isReminderCard(card *model.SlackAttachment) bool {
+	for _, action := range card.Actions {
+		if action.Integration != nil && strings.HasSuffix(action.Integration.URL, "/reminder/action") {
+			return true
+		}
+	}
+	return false
+}
+
+// newReminderAction returns a button of a reminder, handled by handleReminderAction
+func (p *Plugin) newReminderAction(name string, event *calendar.Event, action string) *model.PostAction {
+	return p.newEventAction("/reminder/action", name, "default", event.Id, map[string]interface{}{
+		"action": action,
+		"series": event.RecurringEventId,
+		"title":  event.Summary,
+	})
+}
+
+// remindSnoozed posts the snoozed reminders that are due again
+func (p *Plugin) remindSnoozed(user models.UserDataDto) error {
+	now := time.Now()
+	due, err := p.takeDueReminders(user.UserID, now)
+	if err != nil || len(due) == 0 {
+		return err
+	}
+
+	cal, err := p.getCalendarServiceV2(user)
+	if err != nil {
+		return err
+	}
+	muted, err := p.getMutedEvents(user.UserID)
+	if err != nil {
+		p.API.LogError("Error getting muted events", "err", err.Error())
+	}
+	for _, snoozed := range due {
+		event, err := cal.service.Events.Get(constant.PRIMARY_CALENDAR_ID, snoozed.EventID).Do()
+		if err != nil {
+			p.API.LogError("Error getting snoozed event", "err", err.Error(), "eventID", snoozed.EventID)
+			continue
+		}
+		et := newEventTime(event, time.UTC)
+		if p.isEventDeleted(event) || et.IsOver(now) || isEventMuted(muted, event) {
+			continue
+		}
+
+		reminder := templates.Reminder{Snoozed: true, AllDay: et.AllDay}
+		if !et.AllDay {
+			reminder.MinutesBefore = int(math.Max(0, math.Ceil(et.Start.Sub(now).Minutes())))
+		}
+		if appErr := p.postReminder(user.UserID, user.Settings, event, reminder); appErr != nil {
+			return appErr
+		}
+		p.metrics.reminderLag.Observe(now.Sub(snoozed.RemindAt).Seconds())
+	}
+	return nil
+}
+
+// takeDueReminders removes the snoozed reminders due at now and returns them
+func (p *Plugin) takeDueReminders(userID string, now time.Time) ([]models.SnoozedReminder, error) {
+	p.remindersLock.Lock()
+	defer p.remindersLock.Unlock()
+
+	snoozed, err := p.getSnoozedReminders(userID)
+	if err != nil {
+		return nil, err
+	}
+	var due, pending []models.SnoozedReminder
+	for _, reminder := range snoozed {
+		if reminder.RemindAt.After(now) {
+			pending = append(pending, reminder)
+		} else {
+			due = append(due, reminder)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+	return due, p.setSnoozedReminders(userID, pending)
+}
+
+// snoozeReminder reminds the user of the event again at remindAt, instead of any other time they
+// snoozed it to
+func (p *Plugin) snoozeReminder(userID, eventID string, remindAt time.Time) error {
+	p.remindersLock.Lock()
+	defer p.remindersLock.Unlock()
+
+	snoozed, err := p.getSnoozedReminders(userID)
+	if err != nil {
+		return err
+	}
+	kept := []models.SnoozedReminder{{EventID: eventID, RemindAt: remindAt}}
+	for _, reminder := range snoozed {
+		if reminder.EventID != eventID {
+			kept = append(kept, reminder)
+		}
+	}
+	return p.setSnoozedReminders(userID, kept)
+}
+
+// muteEvent stops the reminders and the update notifications of an event, or of every event of
+// the series when it is part of one. Its snoozed reminders are dropped
+func (p *Plugin) muteEvent(userID string, muted models.MutedEvent, eventID string) error {
+	p.remindersLock.Lock()
+	defer p.remindersLock.Unlock()
+
+	mutedEvents, err := p.getMutedEvents(userID)
+	if err != nil {
+		return err
+	}
+	for _, event := range mutedEvents {
+		if event.EventID == muted.EventID {
+			return nil
+		}
+	}
+	if err := p.setMutedEvents(userID, append(mutedEvents, muted)); err != nil {
+		return err
+	}
+
+	snoozed, err := p.getSnoozedReminders(userID)
+	if err != nil {
+		return err
+	}
+	var kept []models.SnoozedReminder
+	for _, reminder := range snoozed {
+		if reminder.EventID != eventID {
+			kept = append(kept, reminder)
+		}
+	}
+	return p.setSnoozedReminders(userID, kept)
+}
+
+// isEventMuted tells whether the event, or its recurring series, is muted
+func isEventMuted(muted []models.MutedEvent, event *calendar.Event) bool {
+	for _, mutedEvent := range muted {
+		if mutedEvent.EventID == event.Id || (event.RecurringEventId != "" && mutedEvent.EventID == event.RecurringEventId) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Plugin) getSnoozedReminders(userID string) ([]models.SnoozedReminder, error) {
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.SNOOZED_REMINDERS_KEY,
+	})
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snoozed []models.SnoozedReminder
+	if err := json.Unmarshal([]byte(lookup.Value), &snoozed); err != nil {
+		return nil, err
+	}
+	return snoozed, nil
+}
+
+func (p *Plugin) setSnoozedReminders(userID string, snoozed []models.SnoozedReminder) error {
+	value, err := json.Marshal(snoozed)
+	if err != nil {
+		return err
+	}
+	return p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.SNOOZED_REMINDERS_KEY,
+		Value:  string(value),
+	})
+}
+
+func (p *Plugin) getMutedEvents(userID string) ([]models.MutedEvent, error) {
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.MUTED_EVENTS_KEY,
+	})
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var muted []models.MutedEvent
+	if err := json.Unmarshal([]byte(lookup.Value), &muted); err != nil {
+		return nil, err
+	}
+	return muted, nil
+}
+
+func (p *Plugin) setMutedEvents(userID string, muted []models.MutedEvent) error {
+	value, err := json.Marshal(muted)
+	if err != nil {
+		return err
+	}
+	return p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.MUTED_EVENTS_KEY,
+		Value:  string(value),
+	})
+}
+
+// handleReminderAction handles the snooze and mute buttons of the reminders
+func (p *Plugin) handleReminderAction(w http.ResponseWriter, r *http.Request) {
+	var req model.PostActionIntegrationRequest
+	if err := Decode(r.Body, &req); err != nil {
+		p.API.LogError("Parser error", "err", err.Error())
+		return
+	}
+
+	userID, ok := getActionUserID(r, req)
+	if !ok {
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	response := &model.PostActionIntegrationResponse{}
+	defer func() {
+		if err := Encode(w, response); err != nil {
+			p.API.LogError("Error encoding action response", "err", err.Error())
+		}
+	}()
+
+	format := p.getTimeFormat(userID)
+	action, _ := req.Context["action"].(string)
+	eventID, _ := req.Context["evtid"].(string)
+	seriesID, _ := req.Context["series"].(string)
+	title, _ := req.Context["title"].(string)
+
+	now := time.Now().In(format.location).Truncate(time.Minute)
+	var err error
+	switch action {
+	case constant.REMINDER_ACTION_SNOOZE:
+		remindAt := now.Add(constant.SNOOZE_MINUTES * time.Minute)
+		if err = p.snoozeReminder(userID, eventID, remindAt); err == nil {
+			response.EphemeralText = format.T("reminder.snoozed.until", format.Time(remindAt))
+		}
+
+	case constant.REMINDER_ACTION_SNOOZE_UNTIL_START:
+		var event *calendar.Event
+		if event, err = p.getEvent(userID, eventID); err != nil {
+			break
+		}
+		remindAt := newEventTime(event, format.location).Start
+		if !remindAt.After(now) {
+			remindAt = now
+		}
+		if err = p.snoozeReminder(userID, eventID, remindAt); err == nil {
+			response.EphemeralText = format.T("reminder.snoozed.until", format.Time(remindAt))
+		}
+
+	case constant.REMINDER_ACTION_MUTE:
+		muted := models.MutedEvent{EventID: eventID, Title: title}
+		if seriesID != "" {
+			muted.EventID = seriesID
+		}
+		if err = p.muteEvent(userID, muted, eventID); err == nil {
+			response.EphemeralText = format.T("reminder.muted", title)
+		}
+
+	default:
+		p.API.LogError("Unknown reminder action", "action", action)
+		return
+	}
+
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotConnected) {
+			response.EphemeralText = p.userMessage(userID, err)
+			return
+		}
+		p.API.LogError("Error handling reminder action", "action", action, "err", err.Error())
+		response.EphemeralText = format.T("reminder.action.failed", err)
+	}
+}
+
+// getEvent returns an event of the primary calendar of the user
+func (p *Plugin) getEvent(userID, eventID string) (*calendar.Event, error) {
+	cal, err := p.getCalendarService(userID)
+	if err != nil {
+		return nil, err
+	}
+	return cal.service.Events.Get(p.getPrimaryCalendarID(userID), eventID).Do()
+}
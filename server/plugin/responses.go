@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	"google.golang.org/api/calendar/v3"
+)
+
+// maxListedGuests is how many guests are named in a summary before the rest is folded
+const maxListedGuests = 10
+
+// eventResponses are the guests of an event grouped by what they answered, the organizer is not
+// one of them
+type eventResponses struct {
+	Accepted  []*calendar.EventAttendee
+	Declined  []*calendar.EventAttendee
+	Tentative []*calendar.EventAttendee
+	Pending   []*calendar.EventAttendee
+}
+
+func groupResponses(event *calendar.Event) eventResponses {
+	var responses eventResponses
+	for _, attendee := range event.Attendees {
+		if attendee.Organizer || attendee.Resource {
+			continue
+		}
+		switch attendee.ResponseStatus {
+		case constant.EV_STATUS_ACCEPTED:
+			responses.Accepted = append(responses.Accepted, attendee)
+		case constant.EV_STATUS_DECLINED:
+			responses.Declined = append(responses.Declined, attendee)
+		case constant.EV_STATUS_TENTATIVE:
+			responses.Tentative = append(responses.Tentative, attendee)
+		default:
+			responses.Pending = append(responses.Pending, attendee)
+		}
+	}
+	return responses
+}
+
+// printResponses lists who answered what to an event
+func (p *Plugin) printResponses(event *calendar.Event, responses eventResponses, localizer *i18n.Localizer) string {
+	text := localizer.T("responses.title", event.Summary, event.HtmlLink)
+	groups := []struct {
+		title     string
+		attendees []*calendar.EventAttendee
+	}{
+		{responseIcon(constant.EV_STATUS_ACCEPTED) + " " + localizer.T("responses.accepted"), responses.Accepted},
+		{responseIcon(constant.EV_STATUS_DECLINED) + " " + localizer.T("responses.declined"), responses.Declined},
+		{responseIcon(constant.EV_STATUS_TENTATIVE) + " " + localizer.T("responses.maybe"), responses.Tentative},
+		{responseIcon(constant.EV_STATUS_NEED_ACTION) + " " + localizer.T("responses.pending"), responses.Pending},
+	}
+	for _, group := range groups {
+		text += fmt.Sprintf("**%s (%d)**: ", group.title, len(group.attendees))
+		if len(group.attendees) == 0 {
+			text += "-\n"
+			continue
+		}
+		text += p.printAttendees(group.attendees) + "\n"
+	}
+	return text
+}
+
+// postResponses posts the responses of an event to its organizer, with a button to nudge the
+// guests who have not answered yet
+func (p *Plugin) postResponses(userID string, event *calendar.Event) *model.AppError {
+	localizer := p.getLocalizer(userID)
+	responses := groupResponses(event)
+	text := p.printResponses(event, responses, localizer)
+	if len(responses.Pending) == 0 {
+		return p.CreateBotDMPost(userID, text)
+	}
+
+	return p.CreateBotDMPostWithAttachments(userID, text, []*model.SlackAttachment{
+		{
+			Actions: []*model.PostAction{
+				{
+					Name: localizer.T("responses.nudge"),
+					Type: model.POST_ACTION_TYPE_BUTTON,
+					Integration: &model.PostActionIntegration{
+						URL: fmt.Sprintf("%s/plugins/%s/responses/nudge", p.getConfiguration().SiteUrl, manifest.ID),
+						Context: map[string]interface{}{
+							"evtid": event.Id,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// nudgeAttendees sends every pending guest connected to the plugin their own view of the event,
+// with the buttons to answer it. It returns what happened to tell the organizer
+func (p *Plugin) nudgeAttendees(organizerID string, event *calendar.Event) string {
+	var organizer string
+	if user, appErr := p.API.GetUser(organizerID); appErr == nil {
+		organizer = "@" + user.Username
+	}
+
+	var nudged, notConnected []string
+	for _, attendee := range groupResponses(event).Pending {
+		user := p.services.attendeeService.UserByEmail(attendee.Email)
+		if user == nil {
+			notConnected = append(notConnected, attendee.Email)
+			continue
+		}
+
+		cal, err := p.getCalendarService(user.Id)
+		if err != nil {
+			notConnected = append(notConnected, "@"+user.Username)
+			continue
+		}
+		// the attendee's own copy of the event has their response and RSVP buttons
+		attendeeEvent, err := cal.service.Events.Get(constant.PRIMARY_CALENDAR_ID, event.Id).Do()
+		if err != nil {
+			p.API.LogError("Error getting event of attendee", "err", err.Error(), "userID", user.Id)
+			notConnected = append(notConnected, "@"+user.Username)
+			continue
+		}
+
+		format := p.getTimeFormat(user.Id)
+		waiting := organizer
+		if waiting == "" {
+			waiting = format.T("nudge.organizer")
+		}
+		text := format.T("nudge.waiting", waiting) + p.printEventCardText(attendeeEvent, format, time.Time{})
+		card := p.newEventCard(user.Id, attendeeEvent, format, time.Time{})
+		if appErr := p.CreateBotDMEventPostWithCard(user.Id, attendeeEvent, text, card); appErr != nil {
+			p.API.LogError("Error creating bot post", "appErr", appErr.Error())
+			notConnected = append(notConnected, "@"+user.Username)
+			continue
+		}
+		nudged = append(nudged, "@"+user.Username)
+	}
+
+	localizer := p.getLocalizer(organizerID)
+	var text string
+	if len(nudged) > 0 {
+		text += localizer.T("nudge.done", strings.Join(nudged, ", "))
+	} else {
+		text += localizer.T("nudge.none")
+	}
+	if len(notConnected) > 0 {
+		text += localizer.T("nudge.not_connected", strings.Join(notConnected, ", "))
+	}
+	return text
+}
+
+// responseIcon is the emoji shown next to an attendee for their response
+func responseIcon(status string) string {
+	switch status {
+	case constant.EV_STATUS_ACCEPTED:
+		return ":white_check_mark:"
+	case constant.EV_STATUS_DECLINED:
+		return ":x:"
+	case constant.EV_STATUS_TENTATIVE:
+		return ":grey_question:"
+	default:
+		return ":hourglass:"
+	}
+}
+
+// printGuests prints the organizer and the guests of an event with their responses, Mattermost
+// users first then the external guests. Large meetings are folded after maxListedGuests
+func (p *Plugin) printGuests(event *calendar.Event, localizer *i18n.Localizer) string {
+	var text string
+	if event.Organizer != nil {
+		organizer := &calendar.EventAttendee{Email: event.Organizer.Email, DisplayName: event.Organizer.DisplayName}
+		text += localizer.T("event.organizer", p.printAttendee(organizer))
+	}
+
+	var team, external []string
+	responses := groupResponses(event)
+	for _, attendee := range event.Attendees {
+		if attendee.Organizer || attendee.Resource {
+			continue
+		}
+		guest := fmt.Sprintf("%s %s", p.printAttendee(attendee), responseIcon(attendee.ResponseStatus))
+		if p.services.attendeeService.UserByEmail(attendee.Email) != nil {
+			team = append(team, guest)
+		} else {
+			external = append(external, guest)
+		}
+	}
+	if len(team)+len(external) == 0 {
+		return text
+	}
+
+	text += localizer.T("event.guests", len(responses.Accepted), len(responses.Declined), len(responses.Tentative),
+		len(responses.Pending))
+	listed := 0
+	for _, group := range []struct {
+		title  string
+		guests []string
+	}{{localizer.T("event.guests.team"), team}, {localizer.T("event.guests.external"), external}} {
+		if len(group.guests) == 0 {
+			continue
+		}
+		shown := group.guests
+		if listed+len(shown) > maxListedGuests {
+			shown = shown[:maxListedGuests-listed]
+		}
+		listed += len(shown)
+
+		line := strings.Join(shown, ", ")
+		if more := len(group.guests) - len(shown); more > 0 {
+			if line != "" {
+				line += " "
+			}
+			line += localizer.N("event.guests.more", more)
+		}
+		text += fmt.Sprintf("- %s: %s\n", group.title, line)
+	}
+	return text
+}
@@ -0,0 +1,246 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	searchPageSize   = 5
+	maxSearchResults = 100
+
+	// searchDefaultDays is how far the search looks when no end date is given
+	searchDefaultDays = 90
+)
+
+// eventSearch is a search over the user's events between From and To
+type eventSearch struct {
+	Text     string
+	From     time.Time
+	To       time.Time
+	Attendee string
+}
+
+// parseEventSearch parses "<text> [--from date] [--to date] [--attendee @user]", dates are inclusive
+func (p *Plugin) parseEventSearch(args []string, location *time.Location, localizer *i18n.Localizer) (eventSearch, error) {
+	now := time.Now().In(location)
+	search := eventSearch{
+		From: dateOf(now, location),
+	}
+
+	var words []string
+	var to time.Time
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--from", "--to", "--attendee":
+			if i+1 >= len(args) {
+				return search, errors.New(localizer.T("search.missing_value", args[i]))
+			}
+			value := args[i+1]
+			switch args[i] {
+			case "--from":
+				from, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, value, location)
+				if err != nil {
+					return search, errors.New(localizer.T("search.invalid_from"))
+				}
+				search.From = from
+			case "--to":
+				date, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, value, location)
+				if err != nil {
+					return search, errors.New(localizer.T("search.invalid_to"))
+				}
+				to = date.AddDate(0, 0, 1)
+			case "--attendee":
+				email, err := p.services.attendeeService.EmailOf(value)
+				if err != nil {
+					return search, err
+				}
+				search.Attendee = email
+			}
+			i++
+		default:
+			words = append(words, args[i])
+		}
+	}
+
+	search.Text = strings.Join(words, " ")
+	if search.Text == "" && search.Attendee == "" {
+		return search, errors.New(localizer.T("search.missing_text"))
+	}
+
+	search.To = to
+	if search.To.IsZero() {
+		search.To = search.From.AddDate(0, 0, searchDefaultDays)
+	}
+	if !search.From.Before(search.To) {
+		return search, errors.New(localizer.T("search.from_after_to"))
+	}
+	return search, nil
+}
+
+// matches tells whether the event is one the search is looking for
+func (s eventSearch) matches(event *calendar.Event) bool {
+	if email := s.Attendee; email != "" {
+		found := event.Organizer != nil && strings.EqualFold(event.Organizer.Email, email)
+		for _, attendee := range event.Attendees {
+			if strings.EqualFold(attendee.Email, email) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.Text == "" {
+		return true
+	}
+
+	text := strings.ToLower(s.Text)
+	fields := []string{event.Summary, event.Description, event.Location}
+	for _, attendee := range event.Attendees {
+		fields = append(fields, attendee.Email, attendee.DisplayName)
+	}
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchEvents looks for events in the locally synced store for the window it covers and asks
+// Google for the rest of the range. Results are ordered by date
+func (p *Plugin) searchEvents(userID string, cal *CalendarService, search eventSearch, location *time.Location) ([]*calendar.Event, error) {
+	now := time.Now().In(location)
+	cacheStart, cacheEnd := now, now
+
+	storedEvents, err := p.getStoredEvents(userID)
+	if err == nil {
+		windowEnd, lookupErr := p.services.lookupService.Get(models.LookupsRequest{
+			UserID: userID,
+			Key:    constant.EVENTS_WINDOW_END_KEY,
+		})
+		if lookupErr == nil {
+			if end, parseErr := time.Parse(time.RFC3339, windowEnd.Value); parseErr == nil && end.After(now) {
+				cacheEnd = end
+			}
+		}
+	}
+	if cacheStart.Before(search.From) {
+		cacheStart = search.From
+	}
+	if cacheEnd.After(search.To) {
+		cacheEnd = search.To
+	}
+
+	var results []*calendar.Event
+	seen := make(map[string]bool)
+	add := func(event *calendar.Event) {
+		if seen[event.Id] || p.isEventDeleted(event) {
+			return
+		}
+		seen[event.Id] = true
+		results = append(results, event)
+	}
+
+	// the locally synced window
+	if cacheStart.Before(cacheEnd) {
+		window := eventTime{Start: cacheStart, End: cacheEnd}
+		for _, event := range storedEvents {
+			if search.matches(event) && window.Overlaps(newEventTime(event, location)) {
+				add(event)
+			}
+		}
+	} else {
+		cacheStart, cacheEnd = search.From, search.From
+	}
+
+	// remote ranges before and after the synced window
+	remoteRanges := [][2]time.Time{{search.From, cacheStart}, {cacheEnd, search.To}}
+	for _, remote := range remoteRanges {
+		if !remote[0].Before(remote[1]) {
+			continue
+		}
+
+		request := cal.service.Events.List(constant.PRIMARY_CALENDAR_ID).ShowDeleted(false).SingleEvents(true).
+			TimeMin(remote[0].Format(time.RFC3339)).TimeMax(remote[1].Format(time.RFC3339)).OrderBy("startTime")
+		if search.Text != "" {
+			request.Q(search.Text)
+		}
+
+		var pageToken string
+		for ok := true; ok && len(results) < maxSearchResults; ok = pageToken != "" {
+			request.PageToken(pageToken)
+			events, err := request.Do()
+			if err != nil {
+				return nil, err
+			}
+			for _, event := range events.Items {
+				// the text is already matched by Google
+				if search.Attendee == "" || (eventSearch{Attendee: search.Attendee}).matches(event) {
+					add(event)
+				}
+			}
+			pageToken = events.NextPageToken
+		}
+	}
+
+	sortEvents(results, location)
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	return results, nil
+}
+
+// postSearchResults posts a page of results, with a button to get the next page when there is one
+func (p *Plugin) postSearchResults(userID string, search eventSearch, results []*calendar.Event, page int) *model.AppError {
+	localizer := p.getLocalizer(userID)
+	start := page * searchPageSize
+	if start >= len(results) {
+		return p.CreateBotDMPost(userID, localizer.T("search.no_more"))
+	}
+	end := start + searchPageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	text := localizer.T("search.title", search.Text, start+1, end, len(results))
+	if search.Text == "" {
+		text = localizer.T("search.title.attendee", search.Attendee, start+1, end, len(results))
+	}
+	for _, event := range results[start:end] {
+		text += p.printEventSummary(userID, event)
+	}
+
+	if end == len(results) {
+		return p.CreateBotDMPost(userID, text)
+	}
+	return p.CreateBotDMPostWithAttachments(userID, text, []*model.SlackAttachment{
+		{
+			Actions: []*model.PostAction{
+				{
+					Name: localizer.T("search.more"),
+					Type: model.POST_ACTION_TYPE_BUTTON,
+					Integration: &model.PostActionIntegration{
+						URL: fmt.Sprintf("%s/plugins/%s/search/more", p.getConfiguration().SiteUrl, manifest.ID),
+						Context: map[string]interface{}{
+							"text":     search.Text,
+							"from":     search.From.Format(constant.CUSTOM_FORMAT_NO_TIME),
+							"to":       search.To.Format(constant.CUSTOM_FORMAT_NO_TIME),
+							"attendee": search.Attendee,
+							"page":     page + 1,
+						},
+					},
+				},
+			},
+		},
+	})
+}
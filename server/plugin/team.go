@@ -0,0 +1,523 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/apperr"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+	models "github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/models"
+	"google.golang.org/api/calendar/v3"
+)
+
+const (
+	// maxTeamMembers bounds how many members of a channel or group are looked at
+	maxTeamMembers = 200
+
+	// freeBusyBatchSize is the maximum number of calendars of one FreeBusy query
+	freeBusyBatchSize = 50
+)
+
+const (
+	teamStatusFree = iota
+	teamStatusBusy
+	teamStatusOut
+)
+
+// teamMember is a member of a channel or group who connected their Google Calendar
+type teamMember struct {
+	user   *model.User
+	events []*calendar.Event
+}
+
+// memberStatus is what a team member is doing right now
+type memberStatus struct {
+	member teamMember
+	status int
+	// until is when the member is free again, or back when out of office
+	until time.Time
+	// next is when the next busy time of the day starts, when the member is free
+	next time.Time
+	// title is only set for events the viewer is invited to
+	title string
+}
+
+type busyPeriod struct {
+	start time.Time
+	end   time.Time
+	event *calendar.Event
+}
+
+// getTeamUsers returns the users of the target, "~channel", "#channel" or "@group", or of the
+// current channel when there is no target
+func (p *Plugin) getTeamUsers(args *model.CommandArgs, target string, localizer *i18n.Localizer) ([]*model.User, string, error) {
+	var users []*model.User
+	switch {
+	case strings.HasPrefix(target, "@"):
+		name := strings.TrimPrefix(target, "@")
+		group, appErr := p.API.GetGroupByName(name)
+		if appErr != nil {
+			return nil, "", errors.New(localizer.T("team.group_not_found", name))
+		}
+		for page := 0; len(users) < maxTeamMembers; page++ {
+			pageUsers, appErr := p.API.GetGroupMemberUsers(group.Id, page, 100)
+			if appErr != nil {
+				return nil, "", appErr
+			}
+			if len(pageUsers) == 0 {
+				break
+			}
+			users = append(users, pageUsers...)
+		}
+		return users, "@" + name, nil
+	}
+
+	channelID := args.ChannelId
+	if target != "" {
+		name := strings.TrimLeft(target, "~#")
+		channel, appErr := p.API.GetChannelByName(args.TeamId, name, false)
+		if appErr != nil {
+			return nil, "", errors.New(localizer.T("team.channel_not_found", name))
+		}
+		// only the members of a channel can see who is in it
+		if _, appErr := p.API.GetChannelMember(channel.Id, args.UserId); appErr != nil {
+			return nil, "", errors.New(localizer.T("team.channel_not_found", name))
+		}
+		channelID = channel.Id
+	}
+	users, err := p.getChannelUsers(channelID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	title := localizer.T("team.this_channel")
+	if target != "" {
+		title = "~" + strings.TrimLeft(target, "~#")
+	}
+	return users, title, nil
+}
+
+func (p *Plugin) getChannelUsers(channelID string) ([]*model.User, error) {
+	var users []*model.User
+	for page := 0; len(users) < maxTeamMembers; page++ {
+		pageUsers, appErr := p.API.GetUsersInChannel(channelID, "username", page, 100)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if len(pageUsers) == 0 {
+			break
+		}
+		users = append(users, pageUsers...)
+	}
+	return users, nil
+}
+
+// getTeamMembers keeps the users who connected their calendar, with their synced events
+func (p *Plugin) getTeamMembers(users []*model.User) []teamMember {
+	secret := p.getConfiguration().EncryptionSecret
+	var members []teamMember
+	for _, user := range users {
+		if user.IsBot || user.DeleteAt != 0 {
+			continue
+		}
+		if _, err := p.services.userService.GetUserByID(user.Id, secret); err != nil {
+			continue
+		}
+		events, err := p.getStoredEvents(user.Id)
+		if err != nil {
+			p.API.LogError("Error getting stored events", "err", err.Error(), "userID", user.Id)
+		}
+		members = append(members, teamMember{user: user, events: events})
+	}
+	return members
+}
+
+// getFreeBusy asks Google when the members are busy between start and end. Members whose calendar
+// can't be read by the viewer are left out, their synced events are used instead
+func (p *Plugin) getFreeBusy(cal *CalendarService, members []teamMember, start, end time.Time) map[string][]busyPeriod {
+	busy := make(map[string][]busyPeriod)
+	for i := 0; i < len(members); i += freeBusyBatchSize {
+		batch := members[i:]
+		if len(batch) > freeBusyBatchSize {
+			batch = batch[:freeBusyBatchSize]
+		}
+
+		request := &calendar.FreeBusyRequest{
+			TimeMin: start.Format(time.RFC3339),
+			TimeMax: end.Format(time.RFC3339),
+		}
+		for _, member := range batch {
+			request.Items = append(request.Items, &calendar.FreeBusyRequestItem{Id: member.user.Email})
+		}
+		response, err := cal.service.Freebusy.Query(request).Do()
+		if err != nil {
+			p.API.LogError("Error querying free busy", "err", err.Error())
+			continue
+		}
+
+		for email, freeBusy := range response.Calendars {
+			if len(freeBusy.Errors) > 0 {
+				continue
+			}
+			key := strings.ToLower(email)
+			busy[key] = []busyPeriod{}
+			for _, period := range freeBusy.Busy {
+				periodStart, startErr := time.Parse(time.RFC3339, period.Start)
+				periodEnd, endErr := time.Parse(time.RFC3339, period.End)
+				if startErr != nil || endErr != nil {
+					continue
+				}
+				busy[key] = append(busy[key], busyPeriod{start: periodStart, end: periodEnd})
+			}
+		}
+	}
+	return busy
+}
+
+// getMemberStatus works out what the member is doing at now, from their free/busy times and their
+// synced events
+func (p *Plugin) getMemberStatus(member teamMember, freeBusy []busyPeriod, viewerEmail string, now time.Time, location *time.Location) memberStatus {
+	status := memberStatus{member: member, status: teamStatusFree}
+	today := dateOf(now, location)
+	day := eventTime{Start: today, End: today.AddDate(0, 0, 1)}
+
+	periods := append([]busyPeriod{}, freeBusy...)
+	for _, event := range member.events {
+		et := newEventTime(event, location)
+		if event.EventType == oooEventType && !p.isEventDeleted(event) && et.Overlaps(day) && now.Before(et.End) {
+			status.status = teamStatusOut
+			status.until = et.End
+			return status
+		}
+		if p.isBusyEvent(event) && et.Overlaps(day) {
+			periods = append(periods, busyPeriod{start: et.Start, end: et.End, event: event})
+		}
+	}
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].start.Before(periods[j].start)
+	})
+
+	for _, period := range periods {
+		if !period.end.After(now) {
+			continue
+		}
+		if status.status == teamStatusBusy {
+			// busy periods back to back keep the member busy
+			if period.start.After(status.until) {
+				break
+			}
+			if period.end.After(status.until) {
+				status.until = period.end
+			}
+			continue
+		}
+		if period.start.After(now) {
+			status.next = period.start
+			break
+		}
+		status.status = teamStatusBusy
+		status.until = period.end
+	}
+
+	// only the events the viewer is invited to are named
+	for _, period := range periods {
+		if status.status == teamStatusBusy && period.event != nil && !period.start.After(now) && period.end.After(now) &&
+			isInvited(period.event, viewerEmail) {
+			status.title = fmt.Sprintf("[%s](%s)", period.event.Summary, period.event.HtmlLink)
+			break
+		}
+	}
+	return status
+}
+
+// isInvited tells whether the email organizes or is a guest of the event
+func isInvited(event *calendar.Event, email string) bool {
+	if event.Organizer != nil && strings.EqualFold(event.Organizer.Email, email) {
+		return true
+	}
+	for _, attendee := range event.Attendees {
+		if strings.EqualFold(attendee.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderTeamBoard renders the status of the members, the busy ones first then the free ones and
+// the ones out of office
+func renderTeamBoard(title string, statuses []memberStatus, now time.Time, format timeFormat) string {
+	location := format.location
+	sort.SliceStable(statuses, func(i, j int) bool {
+		order := map[int]int{teamStatusBusy: 0, teamStatusFree: 1, teamStatusOut: 2}
+		if statuses[i].status != statuses[j].status {
+			return order[statuses[i].status] < order[statuses[j].status]
+		}
+		return statuses[i].member.user.Username < statuses[j].member.user.Username
+	})
+
+	text := format.T("team.title", title, format.Time(now))
+	text += format.T("team.header")
+	for _, status := range statuses {
+		var state, details string
+		switch status.status {
+		case teamStatusOut:
+			state = format.T("team.out")
+			details = format.T("team.back", printDateLabel(dateOf(status.until, location), now, format))
+			if until := status.until.In(location); until.Hour() != 0 || until.Minute() != 0 {
+				details += " @ " + format.Time(until)
+			}
+		case teamStatusBusy:
+			state = format.T("team.busy")
+			details = format.T("team.until", format.Time(status.until))
+			if status.title != "" {
+				details += " · " + escapeTableCell(status.title)
+			}
+		default:
+			state = format.T("team.free")
+			if !status.next.IsZero() {
+				details = format.T("team.next", format.Time(status.next))
+			} else {
+				details = format.T("team.free_rest")
+			}
+		}
+		text += fmt.Sprintf("| @%s | %s | %s |\n", status.member.user.Username, state, details)
+	}
+	return text
+}
+
+// renderWhosOut lists the members out of office between start and end
+func renderWhosOut(members []teamMember, start, end time.Time, format timeFormat) string {
+	window := eventTime{Start: start, End: end}
+	text := format.T("team.whos_out.title")
+	found := false
+	for _, member := range members {
+		for _, event := range member.events {
+			if event.EventType != oooEventType || event.Status == constant.EV_STATUS_CANCELLED {
+				continue
+			}
+			et := newEventTime(event, format.location)
+			if !et.Overlaps(window) {
+				continue
+			}
+			found = true
+			text += format.T("team.whos_out.member", member.user.Username,
+				printOutOfOfficeDate(et.Start, format), printOutOfOfficeDate(et.End, format))
+		}
+	}
+	if !found {
+		text += format.T("team.whos_out.none")
+	}
+	return text
+}
+
+// getTeamDigestChannels returns the channels the user subscribed to the daily "who's out" post
+func (p *Plugin) getTeamDigestChannels(userID string) ([]string, error) {
+	lookup, err := p.services.lookupService.Get(models.LookupsRequest{
+		UserID: userID,
+		Key:    constant.TEAM_DIGEST_CHANNELS_KEY,
+	})
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var channelIDs []string
+	if err := json.Unmarshal([]byte(lookup.Value), &channelIDs); err != nil {
+		return nil, err
+	}
+	return channelIDs, nil
+}
+
+func (p *Plugin) setTeamDigestChannels(userID string, channelIDs []string) error {
+	value, err := json.Marshal(channelIDs)
+	if err != nil {
+		return err
+	}
+	return p.services.lookupService.Set(models.Lookups{
+		UserID: userID,
+		Key:    constant.TEAM_DIGEST_CHANNELS_KEY,
+		Value:  string(value),
+	})
+}
+
+// getTeamDigestSubscriber returns the user whose subscription posts the daily "who's out" post of
+// the channel, empty when the channel has none. The subscription is kept in the KV store under the
+// channel so the channel gets one post whoever subscribed it
+func (p *Plugin) getTeamDigestSubscriber(channelID string) (string, error) {
+	value, appErr := p.API.KVGet(constant.TEAM_DIGEST_SUBSCRIBER_KEY_PREFIX + channelID)
+	if appErr != nil {
+		return "", appErr
+	}
+	return string(value), nil
+}
+
+func (p *Plugin) setTeamDigestSubscriber(channelID, userID string) error {
+	if appErr := p.API.KVSet(constant.TEAM_DIGEST_SUBSCRIBER_KEY_PREFIX+channelID, []byte(userID)); appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+func (p *Plugin) deleteTeamDigestSubscriber(channelID string) error {
+	if appErr := p.API.KVDelete(constant.TEAM_DIGEST_SUBSCRIBER_KEY_PREFIX + channelID); appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
+// subscribeTeamDigest turns the daily "who's out" post of the channel on or off. Any member of the
+// channel can turn off the post another member turned on
+func (p *Plugin) subscribeTeamDigest(userID, channelID string, subscribe bool) (string, error) {
+	p.teamDigestLock.Lock()
+	defer p.teamDigestLock.Unlock()
+
+	localizer := p.getLocalizer(userID)
+	subscriber, err := p.getTeamDigestSubscriber(channelID)
+	if err != nil {
+		return "", err
+	}
+	if !subscribe {
+		if subscriber == "" {
+			return localizer.T("team.digest.not_subscribed"), nil
+		}
+		if err := p.deleteTeamDigestSubscriber(channelID); err != nil {
+			return "", err
+		}
+		if err := p.removeTeamDigestChannel(subscriber, channelID); err != nil {
+			p.API.LogError("Error removing team digest channel", "err", err.Error(), "userID", subscriber)
+		}
+		return localizer.T("team.digest.unsubscribed"), nil
+	}
+
+	if subscriber != "" {
+		return localizer.T("team.digest.already"), nil
+	}
+	if _, appErr := p.API.AddChannelMember(channelID, p.botID); appErr != nil {
+		return "", errors.New(localizer.T("team.digest.add_bot_failed"))
+	}
+	channelIDs, err := p.getTeamDigestChannels(userID)
+	if err != nil {
+		return "", err
+	}
+	if err := p.setTeamDigestSubscriber(channelID, userID); err != nil {
+		return "", err
+	}
+	if err := p.setTeamDigestChannels(userID, append(channelIDs, channelID)); err != nil {
+		if err := p.deleteTeamDigestSubscriber(channelID); err != nil {
+			p.API.LogError("Error removing team digest subscriber", "err", err.Error(), "channelID", channelID)
+		}
+		return "", err
+	}
+	return localizer.T("team.digest.subscribed", constant.TEAM_DIGEST_TIME), nil
+}
+
+// removeTeamDigestChannel drops the channel from the subscriptions of the user
+func (p *Plugin) removeTeamDigestChannel(userID, channelID string) error {
+	channelIDs, err := p.getTeamDigestChannels(userID)
+	if err != nil {
+		return err
+	}
+	var kept []string
+	for _, id := range channelIDs {
+		if id != channelID {
+			kept = append(kept, id)
+		}
+	}
+	if len(kept) == len(channelIDs) {
+		return nil
+	}
+	return p.setTeamDigestChannels(userID, kept)
+}
+
+// removeTeamDigestSubscriptions releases the channels whose post goes through the subscription of
+// the user, when they disconnect. Another subscriber of the channel, if any, takes it over
+func (p *Plugin) removeTeamDigestSubscriptions(userID string) error {
+	p.teamDigestLock.Lock()
+	defer p.teamDigestLock.Unlock()
+
+	channelIDs, err := p.getTeamDigestChannels(userID)
+	if err != nil {
+		return err
+	}
+	for _, channelID := range channelIDs {
+		subscriber, err := p.getTeamDigestSubscriber(channelID)
+		if err != nil {
+			return err
+		}
+		if subscriber != userID {
+			continue
+		}
+		if err := p.deleteTeamDigestSubscriber(channelID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isTeamDigestSubscriber tells whether the channel gets its post through the subscription of the
+// user. Channels without a subscriber, subscribed before the subscriptions were kept under the
+// channel or released by a user who disconnected, go to the first of their subscribers asking
+func (p *Plugin) isTeamDigestSubscriber(userID, channelID string) (bool, error) {
+	p.teamDigestLock.Lock()
+	defer p.teamDigestLock.Unlock()
+
+	subscriber, err := p.getTeamDigestSubscriber(channelID)
+	if err != nil {
+		return false, err
+	}
+	if subscriber == "" {
+		return true, p.setTeamDigestSubscriber(channelID, userID)
+	}
+	return subscriber == userID, nil
+}
+
+// postTeamDigests posts who's out this week to the channels the user subscribed, every weekday at
+// TEAM_DIGEST_TIME in the user's timezone
+func (p *Plugin) postTeamDigests(user models.UserDataDto) {
+	channelIDs, err := p.getTeamDigestChannels(user.UserID)
+	if err != nil || len(channelIDs) == 0 {
+		return
+	}
+
+	format := p.getTimeFormat(user.UserID)
+	location := format.location
+	now := time.Now().In(location)
+	if now.Format(constant.REMINDER_TIME_FORMAT) != constant.TEAM_DIGEST_TIME ||
+		now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return
+	}
+
+	// from today to the end of the week, weeks end on Sunday
+	start := dateOf(now, location)
+	end := start.AddDate(0, 0, 7-(int(start.Weekday())+6)%7)
+	for _, channelID := range channelIDs {
+		subscriber, err := p.isTeamDigestSubscriber(user.UserID, channelID)
+		if err != nil {
+			p.API.LogError("Error getting team digest subscriber", "err", err.Error(), "channelID", channelID)
+			continue
+		}
+		if !subscriber {
+			continue
+		}
+		users, err := p.getChannelUsers(channelID)
+		if err != nil {
+			p.API.LogError("Error getting channel users", "err", err.Error(), "channelID", channelID)
+			continue
+		}
+		text := renderWhosOut(p.getTeamMembers(users), start, end, format)
+		if _, appErr := p.API.CreatePost(&model.Post{
+			UserId:    p.botID,
+			ChannelId: channelID,
+			Message:   text,
+		}); appErr != nil {
+			p.API.LogError("Couldn't create bot post", "channelID", channelID, "err", appErr.Error())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/templates"
+	"google.golang.org/api/calendar/v3"
+)
+
+// renderTemplate renders a bot post with the configured templates. When they fail on real data,
+// the error is logged and the default templates are used so the user still gets the post
+func (p *Plugin) renderTemplate(name string, data interface{}) string {
+	if set := p.getConfiguration().templates; set != nil {
+		text, err := set.Execute(name, data)
+		if err == nil {
+			return text
+		}
+		p.API.LogError("Error rendering message template, using the default one", "template", name, "err", err.Error())
+	}
+
+	text, err := templates.Default().Execute(name, data)
+	if err != nil {
+		p.API.LogError("Error rendering default message template", "template", name, "err", err.Error())
+	}
+	return text
+}
+
+// newTemplateEvent gives the templates the event as seen by the viewer on a day, a zero day shows
+// the event as a whole
+func (p *Plugin) newTemplateEvent(item *calendar.Event, format timeFormat, day time.Time) templates.Event {
+	localized := templates.Localized{Localizer: format.localizer}
+	event := templates.Event{
+		Localized:   localized,
+		ID:          item.Id,
+		Title:       item.Summary,
+		Link:        item.HtmlLink,
+		When:        printEventWhen(newEventTime(item, format.location), format, day),
+		Location:    item.Location,
+		Meet:        meetLink(item),
+		Where:       item.Location,
+		Description: item.Description,
+		Repeats:     printRecurrence(item.Recurrence, format.localizer),
+		Status:      printEventStatus(item.Status, format),
+	}
+	if event.Where == "" && item.ConferenceData != nil {
+		event.Where = item.HangoutLink
+	}
+
+	if item.Attendees != nil {
+		event.Guests = p.printGuests(item, format.localizer)
+	}
+	responses := groupResponses(item)
+	event.GuestCounts = templates.GuestCounts{
+		Yes:      len(responses.Accepted),
+		No:       len(responses.Declined),
+		Maybe:    len(responses.Tentative),
+		Awaiting: len(responses.Pending),
+	}
+
+	if self := p.retrieveMyselfForEvent(item); self != nil {
+		event.Response = self.ResponseStatus
+		event.Going = p.printGoing(item, self, format)
+	}
+
+	if item.Organizer != nil && item.Organizer.Self {
+		event.Organizer = true
+		event.DeleteLink = fmt.Sprintf("%s/plugins/%s/delete?evtid=%s", p.getConfiguration().SiteUrl, manifest.ID, item.Id)
+	}
+	return event
+}
+
+// printEventCardText prints what the event template has to say about an event shown in a card
+func (p *Plugin) printEventCardText(item *calendar.Event, format timeFormat, day time.Time) string {
+	event := p.newTemplateEvent(item, format, day)
+	event.Card = true
+	return p.renderTemplate(templates.EventTemplate, event)
+}
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-server/v5/model"
+	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/internal/i18n"
+)
+
+// timeFormat renders dates and times for a viewer, in their Mattermost timezone and language and
+// with their 12 or 24-hour clock preference
+type timeFormat struct {
+	location  *time.Location
+	military  bool
+	localizer *i18n.Localizer
+}
+
+// getTimeFormat returns the format of the user. Without a timezone in Mattermost, the timezone of
+// their primary calendar is used, and UTC when that is unknown too
+func (p *Plugin) getTimeFormat(userID string) timeFormat {
+	format := timeFormat{location: time.UTC, localizer: i18n.NewLocalizer(i18n.DefaultLocale)}
+
+	hasTimezone := false
+	if mmUser, appErr := p.API.GetUser(userID); appErr == nil {
+		format.localizer = i18n.NewLocalizer(mmUser.Locale)
+		if timezone := model.GetPreferredTimezone(mmUser.Timezone); timezone != "" {
+			if location, err := time.LoadLocation(timezone); err == nil {
+				format.location = location
+				hasTimezone = true
+			}
+		}
+	}
+	if !hasTimezone {
+		if location, err := p.getPrimaryCalendarLocation(userID); err == nil {
+			format.location = location
+		}
+	}
+
+	preferences, appErr := p.API.GetPreferencesForUser(userID)
+	if appErr != nil {
+		return format
+	}
+	for _, preference := range preferences {
+		if preference.Category == model.PREFERENCE_CATEGORY_DISPLAY_SETTINGS && preference.Name == model.PREFERENCE_NAME_USE_MILITARY_TIME {
+			format.military = preference.Value == "true"
+		}
+	}
+	return format
+}
+
+// Time prints the time of t in the viewer's timezone, with the zone abbreviation
+func (f timeFormat) Time(t time.Time) string {
+	t = t.In(f.location)
+	return f.localizer.Clock(t, f.military) + " " + t.Format("MST")
+}
+
+// Clock prints the time of t in its own location, without the zone
+func (f timeFormat) Clock(t time.Time) string {
+	return f.localizer.Clock(t, f.military)
+}
+
+// Date prints the date of t in the viewer's timezone
+func (f timeFormat) Date(t time.Time) string {
+	return f.localizer.Date(t.In(f.location))
+}
+
+// ShortDate prints the date of t in the viewer's timezone, without the year
+func (f timeFormat) ShortDate(t time.Time) string {
+	return f.localizer.ShortDate(t.In(f.location))
+}
+
+// T translates a message in the viewer's language
+func (f timeFormat) T(id string, args ...interface{}) string {
+	return f.localizer.T(id, args...)
+}
+
+// N translates a message in the viewer's language, in the plural form matching count
+func (f timeFormat) N(id string, count int, args ...interface{}) string {
+	return f.localizer.N(id, count, args...)
+}
@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"io"
 	"math/rand"
+	"net/http"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-api/experimental/command"
+	"github.com/mattermost/mattermost-server/v5/model"
 	"github.com/pkg/errors"
 	"github.com/sshindanai/Mattermost-Google-Calendar-Plugin/server/constant"
 	"google.golang.org/api/calendar/v3"
@@ -44,8 +46,9 @@ func HandleAllowNotiBooltoa(allowNoti bool) string {
 	return constant.NOT_ALLOW_NOTIFY
 }
 
-func (p *Plugin) insertSort(data []*calendar.Event, el *calendar.Event) []*calendar.Event {
-	index := sort.Search(len(data), func(i int) bool { return data[i].Start.DateTime > el.Start.DateTime })
+func (p *Plugin) insertSort(data []*calendar.Event, el *calendar.Event, location *time.Location) []*calendar.Event {
+	elTime := newEventTime(el, location)
+	index := sort.Search(len(data), func(i int) bool { return elTime.before(newEventTime(data[i], location)) })
 	data = append(data, &calendar.Event{})
 	copy(data[index+1:], data[index:])
 	data[index] = el
@@ -80,9 +83,54 @@ func (p *Plugin) eventIsOld(userID string, event *calendar.Event) bool {
 		return false
 	}
 
-	now := time.Now().In(userLocation)
-	tEnd, _ := time.Parse(time.RFC3339, event.End.DateTime)
-	return now.After(tEnd)
+	return newEventTime(event, userLocation).IsOver(time.Now())
+}
+
+// formatEventDateTimeInput formats an event boundary the same way users type it in commands,
+// all-day events use the date only and show the inclusive end date
+func formatEventDateTimeInput(dt *calendar.EventDateTime, location *time.Location, isEnd bool) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.DateTime == "" {
+		date, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, dt.Date, location)
+		if err != nil {
+			return dt.Date
+		}
+		if isEnd {
+			date = date.AddDate(0, 0, -1)
+		}
+		return date.Format(constant.CUSTOM_FORMAT_NO_TIME)
+	}
+	t, err := time.Parse(time.RFC3339, dt.DateTime)
+	if err != nil {
+		return ""
+	}
+	return t.In(location).Format(constant.CUSTOM_FORMAT)
+}
+
+// parseEventDateTimeInput parses YYYY-MM-DD@HH:MM, or YYYY-MM-DD for an all-day event, into an event
+// boundary. Google treats the end date of all-day events as exclusive so the inclusive end is shifted
+func parseEventDateTimeInput(input string, location *time.Location, isEnd bool) (*calendar.EventDateTime, time.Time, error) {
+	input = strings.TrimSpace(input)
+	if t, err := time.ParseInLocation(constant.CUSTOM_FORMAT, input, location); err == nil {
+		return &calendar.EventDateTime{
+			DateTime:   t.Format(time.RFC3339),
+			NullFields: []string{"Date"},
+		}, t, nil
+	}
+
+	t, err := time.ParseInLocation(constant.CUSTOM_FORMAT_NO_TIME, input, location)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if isEnd {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &calendar.EventDateTime{
+		Date:       t.Format(constant.CUSTOM_FORMAT_NO_TIME),
+		NullFields: []string{"DateTime"},
+	}, t, nil
 }
 
 var src = rand.NewSource(time.Now().UnixNano())
@@ -123,15 +171,56 @@ func Decode(r io.Reader, vPrt interface{}) error {
 	return json.NewDecoder(r).Decode(vPrt)
 }
 
+// getActionUserID returns the user who clicked a button of a post. The user ID of the body can be
+// forged, only the Mattermost-User-ID header set by the server is trusted and both must match
+func getActionUserID(r *http.Request, req model.PostActionIntegrationRequest) (string, bool) {
+	userID := r.Header.Get(constant.MATTERMOST_USER_KEY)
+	if userID == "" || userID != req.UserId {
+		return "", false
+	}
+	return userID, true
+}
+
+// getDialogUserID returns the user who submitted a dialog, checked like getActionUserID
+func getDialogUserID(r *http.Request, req model.SubmitDialogRequest) (string, bool) {
+	userID := r.Header.Get(constant.MATTERMOST_USER_KEY)
+	if userID == "" || userID != req.UserId {
+		return "", false
+	}
+	return userID, true
+}
+
 type CreateEventDialog struct {
 	EvName        string
 	StartDateTime string
 	EndDateTime   string
 }
 
+type EditEventDialog struct {
+	Title         string
+	StartDateTime string
+	EndDateTime   string
+	Location      string
+	Description   string
+	Attendees     string
+	SendUpdates   string
+}
+
 type SetSettingsDialog struct {
-	AllowNotify         bool
-	TimeNotiBeforeEvent string
+	AllowNotify            bool
+	TimeNotiBeforeEvent    string
+	AllDayReminderTime     string
+	SyncNotificationLayout string
+	NotifyOnDecline        bool
+	OOOAutoReply           bool
+	OOOBackupContact       string
+	QuietHoursStart        string
+	QuietHoursEnd          string
+	DigestMode             string
+	InviteDelivery         string
+	UpdateDelivery         string
+	ReminderDelivery       string
+	DigestDelivery         string
 }
 
 type DisconnectDialog struct {